@@ -4,23 +4,25 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+
+	"adventofcode/intcode"
 )
 
 func main() {
 	scanner := bufio.NewScanner(os.Stdin)
 	scanner.Scan()
-	p, err := newProgram(scanner.Text())
+	c, err := intcode.NewComputer(scanner.Text(), nil)
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		return
 	}
 
-	err = p.run()
+	err = c.Run()
 	if err != nil {
 		fmt.Printf("error running program: %s\n", err.Error())
 		return
 	}
 
 	// Print out the first value of the programs memory
-	fmt.Printf("Value at zero index is: %d\n", p.readAddr(0))
+	fmt.Printf("Value at zero index is: %d\n", c.ReadAddr(0))
 }
@@ -4,38 +4,34 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+
+	"adventofcode/intcode"
 )
 
 func main() {
 	scanner := bufio.NewScanner(os.Stdin)
 	scanner.Scan()
 
-	template, err := newComputer(scanner.Text())
+	const requiredResult = 19690720
+
+	search, err := intcode.NewInputSearch(scanner.Text(), 0, requiredResult,
+		intcode.Param{Addr: 1, Min: 0, Max: 99}, intcode.Param{Addr: 2, Min: 0, Max: 99})
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		return
 	}
 
-	const requiredResult = 19690720
-
-	for noun := 0; noun < 100; noun++ {
-		for verb := 0; verb < 100; verb++ {
-			c := template.clone()
-			c.storeAtAddr(1, noun)
-			c.storeAtAddr(2, verb)
-
-			err = c.run()
-			if err != nil {
-				fmt.Printf("error running program: %s\n", err.Error())
-				return
-			}
-
-			if c.readAddr(0) == requiredResult {
-				fmt.Printf("Found result at noun: %d and verb %d (val at zero is %d)\n", noun, verb, c.readAddr(0))
-				fmt.Printf("Calculated value 100 * noun + verb is: %d\n", (noun*100)+verb)
-				return
-			}
-		}
+	values, err := search.Solve()
+	if err == intcode.ErrNoSolution {
+		fmt.Printf("No valid noun verb combination found for %d\n", requiredResult)
+		return
+	}
+	if err != nil {
+		fmt.Printf("error running program: %s\n", err.Error())
+		return
 	}
-	fmt.Printf("No valid noun verb combination found for %d\n", requiredResult)
+
+	noun, verb := values[0], values[1]
+	fmt.Printf("Found result at noun: %d and verb %d (val at zero is %d)\n", noun, verb, requiredResult)
+	fmt.Printf("Calculated value 100 * noun + verb is: %d\n", (noun*100)+verb)
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -25,13 +27,13 @@ func main() {
 		inputText = scanner.Text()
 	}
 
-	c, err := newComputer(inputText, scanner)
+	c, err := intcode.NewComputer(inputText, scanner)
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		os.Exit(1)
 	}
 
-	err = c.run()
+	err = c.Run()
 	if err != nil {
 		fmt.Printf("error running program: %s\n", err.Error())
 		os.Exit(1)
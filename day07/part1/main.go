@@ -6,7 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 
-	"gonum.org/v1/gonum/stat/combin"
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -26,37 +26,26 @@ func main() {
 		inputText = scanner.Text()
 	}
 
-	phasesSettings := combin.Permutations(5, 5)
-
-	var maxOutput int
-	var maxPerm []int
-	for _, perm := range phasesSettings {
-		c, err := newSeriesComputer(inputText, "AMP A", "AMP B", "AMP C", "AMP D", "AMP E")
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		c.runAsync()
-
-		err = c.loadPhases(perm)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		c.input(0)
-
-		output := c.output()
+	search, err := intcode.NewPhaseSearch(inputText)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
-		if output > maxOutput {
-			maxOutput = output
-			maxPerm = perm
-		}
+	results, err := search.Top(0)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(results) == 0 {
+		fmt.Println("no phase settings to search")
+		os.Exit(1)
+	}
 
-		fmt.Printf("perm %v gives output value %d\n", perm, output)
+	for _, result := range results {
+		fmt.Printf("perm %v gives output value %d\n", result.Phases, result.Signal)
 	}
 
 	fmt.Println()
-	fmt.Printf("max perm %v gives output value %d\n", maxPerm, maxOutput)
+	fmt.Printf("max perm %v gives output value %d\n", results[0].Phases, results[0].Signal)
 }
@@ -6,7 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 
-	"gonum.org/v1/gonum/stat/combin"
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -26,44 +26,28 @@ func main() {
 		inputText = scanner.Text()
 	}
 
-	phasesSettings := combin.Permutations(5, 5)
-	// shift all permuations to range 5 ot 9
-	for _, perm := range phasesSettings {
-		for i, val := range perm {
-			perm[i] = val + 5
-		}
+	search, err := intcode.NewPhaseSearch(inputText)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
+	search.Phases = []int{5, 6, 7, 8, 9}
+	search.Feedback = true
 
-	var maxOutput int
-	var maxPerm []int
-	for _, perm := range phasesSettings {
-		c, err := newFeedbackComputer(inputText, "AMP A", "AMP B", "AMP C", "AMP D", "AMP E")
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		c.runAsync()
-
-		err = c.loadPhases(perm)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		c.input(0)
-		c.waitForCompletion()
-
-		output := c.output()
-
-		if output > maxOutput {
-			maxOutput = output
-			maxPerm = perm
-		}
+	results, err := search.Top(0)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(results) == 0 {
+		fmt.Println("no phase settings to search")
+		os.Exit(1)
+	}
 
-		fmt.Printf("perm %v gives output value %d\n", perm, output)
+	for _, result := range results {
+		fmt.Printf("perm %v gives output value %d\n", result.Phases, result.Signal)
 	}
 
 	fmt.Println()
-	fmt.Printf("max perm %v gives output value %d\n", maxPerm, maxOutput)
+	fmt.Printf("max perm %v gives output value %d\n", results[0].Phases, results[0].Signal)
 }
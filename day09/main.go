@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -24,14 +26,15 @@ func main() {
 		inputText = scanner.Text()
 	}
 
-	c, err := newComputer(inputText, scanner)
+	c, err := intcode.NewComputer(inputText, scanner)
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		os.Exit(1)
 	}
-	c.disableLog = true
+	c.DisableLog = true
+	c.Fast = true
 
-	err = c.run()
+	err = c.Run()
 	if err != nil {
 		fmt.Printf("error running program: %s\n", err.Error())
 		os.Exit(1)
@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -25,25 +27,12 @@ func main() {
 		inputText = scanner.Text()
 	}
 
-	in := make(chan int64)
-	out := make(chan int64)
-	c, err := newChannelComputer(inputText, in, out)
+	c, err := intcode.NewComputer(inputText, nil)
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		os.Exit(1)
 	}
-	c.disableLog = true
-
-	// Start computer running
-	endCh := make(chan struct{})
-	go func() {
-		err = c.run()
-		if err != nil {
-			fmt.Printf("error running program: %s\n", err.Error())
-			os.Exit(1)
-		}
-		close(endCh)
-	}()
+	c.DisableLog = true
 
 	const (
 		Up    = 1
@@ -76,25 +65,36 @@ func main() {
 	direction := Up
 	var currentPanel int64 = 1
 
-	terminated := false
-	for !terminated {
-		select {
+	var robotOut []int64
+	for {
+		status, err := c.Resume()
+		if err != nil {
+			fmt.Printf("error running program: %s\n", err.Error())
+			os.Exit(1)
+		}
+		if status == intcode.Halted {
+			break
+		}
 
-		case in <- currentPanel:
+		switch status {
+		case intcode.NeedsInput:
 			// Feed current panel color
-		case color := <-out:
-			// If we have an output paint the panel
-			panels[cursor] = color
-			move := <-out
-			if move == 0 {
+			c.SendInput(currentPanel)
+		case intcode.HasOutput:
+			// Outputs come in pairs, the color to paint the panel then the direction to turn
+			robotOut = append(robotOut, c.LastOutput())
+			if len(robotOut) < 2 {
+				break
+			}
+			panels[cursor] = robotOut[0]
+			if robotOut[1] == 0 {
 				direction = turnLeft[direction]
 			} else {
 				direction = turnRight[direction]
 			}
 			cursor = moveForward[direction](cursor)
 			currentPanel = panels[cursor]
-		case <-endCh:
-			terminated = true
+			robotOut = robotOut[:0]
 		}
 	}
 
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -25,17 +27,17 @@ func main() {
 	}
 
 	out := make(chan int64)
-	c, err := newChannelComputer(inputText, nil, out)
+	c, err := intcode.NewChannelComputer(inputText, nil, out)
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		os.Exit(1)
 	}
-	c.disableLog = true
+	c.DisableLog = true
 
 	// Start computer running
 	endCh := make(chan struct{})
 	go func() {
-		err = c.run()
+		err = c.Run()
 		if err != nil {
 			fmt.Printf("error running program: %s\n", err.Error())
 			os.Exit(1)
@@ -49,7 +51,12 @@ func main() {
 	completed := false
 	for !completed {
 		select {
-		case x := <-out:
+		case x, ok := <-out:
+			if !ok {
+				// Output is closed once the program halts
+				completed = true
+				break
+			}
 			y := <-out
 			tileType := TileType(<-out)
 			records[tileType] = append(records[tileType], point{x: x, y: y})
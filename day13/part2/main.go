@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/exec"
 	"time"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -26,30 +28,21 @@ func main() {
 		inputText = scanner.Text()
 	}
 
-	in := make(chan int64)
-	out := make(chan int64)
-	c, err := newChannelComputer(inputText, in, out)
+	c, err := intcode.NewComputer(inputText, nil)
 	if err != nil {
 		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
 		os.Exit(1)
 	}
-	c.disableLog = true
-	c.disableOutLog = true
-	c.memory[0] = 2
-
-	// Start computer running
-	end := make(chan struct{})
-	go func() {
-		err = c.run()
-		if err != nil {
-			fmt.Printf("error running program: %s\n", err.Error())
-			os.Exit(1)
-		}
-		close(end)
-	}()
+	c.DisableLog = true
+	c.DisableOutLog = true
+	c.WriteAddr(0, 2)
 
 	paddleGame := newGame()
-	paddleGame.runGameloop(in, out, end)
+	err = paddleGame.runGameloop(c)
+	if err != nil {
+		fmt.Printf("game stopped with score %d, error running program: %s\n", paddleGame.score, err.Error())
+		os.Exit(1)
+	}
 }
 
 type JoyPos int64
@@ -73,23 +66,35 @@ func newGame() *game {
 
 const scoreOutput = -1
 
-func (g *game) runGameloop(in chan<- int64, out <-chan int64, end <-chan struct{}) {
+func (g *game) runGameloop(c *intcode.Computer) error {
+	var tile []int64
 	for {
-		select {
-		case in <- int64(g.calcOptimalJoystickPos()):
+		status, err := c.Resume()
+		if err != nil {
+			g.refreshScreen()
+			return err
+		}
+
+		switch status {
+		case intcode.Halted:
 			g.refreshScreen()
-		case startVal := <-out:
-			if startVal == scoreOutput {
-				<-out //ignore second arg as the we only care about the score
-				g.score = int(<-out)
+			return nil
+		case intcode.NeedsInput:
+			c.SendInput(int64(g.calcOptimalJoystickPos()))
+			g.refreshScreen()
+		case intcode.HasOutput:
+			// Each tile is drawn with three outputs, x, y then the tile type
+			tile = append(tile, c.LastOutput())
+			if len(tile) < 3 {
 				break
 			}
-			p := point{x: int(startVal), y: int(<-out)}
-			tileType := TileType(<-out)
-			g.setTile(p, tileType)
-		case <-end:
-			g.refreshScreen()
-			return
+			if tile[0] == scoreOutput {
+				// ignore second arg as the we only care about the score
+				g.score = int(tile[2])
+			} else {
+				g.setTile(point{x: int(tile[0]), y: int(tile[1])}, TileType(tile[2]))
+			}
+			tile = tile[:0]
 		}
 	}
 }
@@ -8,6 +8,8 @@ import (
 	"os/exec"
 	"strconv"
 	"time"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -27,9 +29,20 @@ func main() {
 		inputText = scanner.Text()
 	}
 
+	template, err := intcode.NewComputer(inputText, nil)
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	template.DisableLog = true
+	template.DisableOutLog = true
+	template.Fast = true
+	// a move takes far fewer instructions, a droid which never answers stops rather than hanging
+	template.MaxInstructions = 1000000
+
 	clearScreen()
 	fmt.Println("mapping out corridors...")
-	field := generateField(inputText)
+	field := generateField(template)
 	fmt.Println("releasing oxygen in 5 seconds...")
 	time.Sleep(5 * time.Second)
 	field.releaseOxygen()
@@ -120,7 +133,20 @@ func nearestPoints(p point) map[Move]point {
 	}
 }
 
-func generateField(inputProgram string) *field {
+// sendMove moves the droid and returns the status it reports
+func sendMove(c *intcode.Computer, m Move) Out {
+	c.SendInput(int64(m))
+	status, err := c.Resume()
+	if err != nil {
+		panic(err)
+	}
+	if status != intcode.HasOutput {
+		panic(fmt.Sprintf("unexpected computer status %s", status))
+	}
+	return Out(c.LastOutput())
+}
+
+func generateField(template *intcode.Computer) *field {
 	result := newField()
 
 	// Keep looping until all nodes have been visited
@@ -139,9 +165,9 @@ func generateField(inputProgram string) *field {
 		//result.print()
 
 		// Get a computer and move to the point
-		c, _ := newInOutComputer(inputProgram)
+		c := template.Clone()
 		for _, m := range path {
-			output := Out(c.Input(int(m)))
+			output := sendMove(c, m)
 			if output == OutWall {
 				panic("unexpected wall")
 			}
@@ -150,13 +176,13 @@ func generateField(inputProgram string) *field {
 		// Work out the nearest points (and the directions to them)
 		nearest := nearestPoints(node)
 		for dir, np := range nearest {
-			output := Out(c.Input(int(dir)))
+			output := sendMove(c, dir)
 			switch output {
 			case OutWall:
 				result.walls[np] = struct{}{}
 			case OutOK, OutOxygen:
 				// Reverse back
-				revOut := Out(c.Input(int(reverseMove[dir])))
+				revOut := sendMove(c, reverseMove[dir])
 				if revOut == OutWall {
 					panic("unexpected wall when reversing")
 				}
@@ -8,6 +8,8 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+
+	"adventofcode/intcode"
 )
 
 func main() {
@@ -28,13 +30,28 @@ func main() {
 	}
 
 	clearScreen()
-	comp, _ := newComputer(inputText, nil)
-	comp.disableLog = true
-	comp.disableOutLog = true
-
-	comp.run()
+	comp, err := intcode.NewComputer(inputText, nil)
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	comp.DisableLog = true
+	comp.DisableOutLog = true
+	// the robot runs the same program from the start
+	robotComp := comp.Clone()
+
+	camera := intcode.NewASCII(comp)
+	status, err := camera.Resume()
+	if err != nil {
+		fmt.Printf("camera failed, %s\n", err.Error())
+		os.Exit(1)
+	}
+	if status != intcode.Halted {
+		fmt.Println("camera failed, it is waiting for input")
+		os.Exit(1)
+	}
 
-	state := newMapState(comp.outputs)
+	state := newMapState(camera.ReadLines())
 	state.print()
 	alignment := state.calcAlignment()
 	fmt.Printf("Alignment: %d\n", alignment)
@@ -55,22 +72,34 @@ func main() {
 	// L,4,L,4,L,6,                          C
 	// L,6,R,12,L,6,L,8,L,8                  A
 
-	newInput := "A,A,B,C,B,A,C,B,C,A\nL,6,R,12,L,6,L,8,L,8\nL,6,R,12,R,8,L,8\nL,4,L,4,L,6\nn\n"
+	movement := []string{"A,A,B,C,B,A,C,B,C,A", "L,6,R,12,L,6,L,8,L,8", "L,6,R,12,R,8,L,8", "L,4,L,4,L,6", "n"}
 
-	newInputMemory := "2" + inputText[1:]
-	inComp, _ := newInOutComputer(newInputMemory)
+	// wake the robot up so it follows the movement routines
+	if err := robotComp.WriteAddr(0, 2); err != nil {
+		fmt.Printf("unable to wake the robot, %s\n", err.Error())
+		os.Exit(1)
+	}
 
-	go func() {
-		for _, v := range newInput {
-			inComp.in <- int64(v)
-		}
-	}()
+	robot := intcode.NewASCII(robotComp)
+	for _, line := range movement {
+		robot.SendLine(line)
+	}
+	status, err = robot.Resume()
+	if err != nil {
+		fmt.Printf("robot failed, %s\n", err.Error())
+		os.Exit(1)
+	}
+	if status != intcode.Halted {
+		fmt.Println("robot failed, it is waiting for more movement routines")
+		os.Exit(1)
+	}
 
-	var lastval int64
-	for a := range inComp.out {
-		lastval = a
+	dust, ok := robot.LastValue()
+	if !ok {
+		fmt.Println("robot did not report the dust collected")
+		os.Exit(1)
 	}
-	fmt.Printf("DUST COLLECTED: %d\n", lastval)
+	fmt.Printf("DUST COLLECTED: %d\n", dust)
 }
 
 func clearScreen() {
@@ -86,15 +115,11 @@ type mapState struct {
 	length         int
 }
 
-func newMapState(out []int64) *mapState {
-	byteLine := make([]byte, len(out))
-	for i, v := range out {
-		byteLine[i] = byte(v)
+func newMapState(lines []string) *mapState {
+	// the camera image ends with an empty line
+	for len(lines) != 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
 	}
-	outString := string(byteLine)
-
-	lines := strings.Split(outString, "\n")
-	lines = lines[:len(lines)-2] // Remove last empty line
 	depth := len(lines)
 	length := len(lines[0])
 
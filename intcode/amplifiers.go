@@ -0,0 +1,259 @@
+package intcode
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PhaseStrategy chooses the phase settings tried by a PhaseSearch
+type PhaseStrategy interface {
+	// Settings calls yield with each setting of a phase per amplifier to try, stopping if yield returns
+	// false. The setting may be reused once yield returns.
+	Settings(amplifiers int, phases []int, yield func(setting []int) bool)
+}
+
+// Permutations tries every ordering of distinct phases, as the amplifiers of day 7 require
+type Permutations struct{}
+
+// Settings yields each permutation of the phases
+func (Permutations) Settings(amplifiers int, phases []int, yield func(setting []int) bool) {
+	setting := make([]int, amplifiers)
+	used := make([]bool, len(phases))
+	var permute func(i int) bool
+	permute = func(i int) bool {
+		if i == amplifiers {
+			return yield(setting)
+		}
+		for p, phase := range phases {
+			if used[p] {
+				continue
+			}
+			used[p] = true
+			setting[i] = phase
+			ok := permute(i + 1)
+			used[p] = false
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	permute(0)
+}
+
+// Sequences tries every setting, allowing amplifiers to share a phase
+type Sequences struct{}
+
+// Settings yields each sequence of phases
+func (Sequences) Settings(amplifiers int, phases []int, yield func(setting []int) bool) {
+	if len(phases) == 0 {
+		return
+	}
+	setting := make([]int, amplifiers)
+	indexes := make([]int, amplifiers)
+	for i := range setting {
+		setting[i] = phases[0]
+	}
+	for {
+		if !yield(setting) {
+			return
+		}
+		// increment the indexes as a number in base len(phases), last amplifier first
+		i := amplifiers - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(phases) {
+				setting[i] = phases[indexes[i]]
+				break
+			}
+			indexes[i] = 0
+			setting[i] = phases[0]
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// PhaseResult is the signal produced by a phase setting
+type PhaseResult struct {
+	Phases []int
+	Signal int64
+}
+
+// PhaseSearch finds the phase settings giving the highest signal from a chain of amplifiers all running the
+// same program. The program is parsed once and cloned for each amplifier.
+type PhaseSearch struct {
+	// Amplifiers is the number of amplifiers in the chain
+	Amplifiers int
+	// Phases are the phase settings available to the amplifiers
+	Phases []int
+	// Feedback connects the output of the last amplifier to the input of the first
+	Feedback bool
+	// Strategy chooses the settings to try, Permutations if not set
+	Strategy PhaseStrategy
+	// Workers is the number of settings tried at once, the number of CPUs if not set
+	Workers int
+
+	template *Computer
+}
+
+// NewPhaseSearch creates a search of five amplifiers with the phases 0 to 4, as in part 1 of day 7
+func NewPhaseSearch(inputText string) (*PhaseSearch, error) {
+	template, err := NewComputer(inputText, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input to int code memory")
+	}
+	template.DisableLog = true
+	template.DisableOutLog = true
+	template.Fast = true
+	return &PhaseSearch{Amplifiers: 5, Phases: []int{0, 1, 2, 3, 4}, template: template}, nil
+}
+
+// Signal runs the amplifiers with a phase setting and an input signal of zero, returning the last output of
+// the final amplifier
+func (p *PhaseSearch) Signal(phases []int) (int64, error) {
+	return p.signal(context.Background(), phases)
+}
+
+// signal runs the amplifiers as clones of the template, which workers share as cloning is safe to do at once
+func (p *PhaseSearch) signal(ctx context.Context, phases []int) (int64, error) {
+	if len(phases) != p.Amplifiers {
+		return 0, errors.Errorf("incorrect number of phases provided (got %d, want %d)", len(phases), p.Amplifiers)
+	}
+
+	n := NewNetwork()
+	amps := make([]*Node, p.Amplifiers)
+	for i, phase := range phases {
+		comp := p.template.Clone()
+		comp.Label = ampLabel(i)
+		amps[i] = n.AddNode(comp)
+		amps[i].Send(int64(phase))
+		if i > 0 {
+			n.Connect(amps[i-1], amps[i])
+		}
+	}
+	last := amps[len(amps)-1]
+	if p.Feedback {
+		n.Connect(last, amps[0])
+	}
+	amps[0].Send(0)
+
+	if err := n.RunContext(ctx); err != nil {
+		return 0, err
+	}
+	if len(last.comp.Outputs()) == 0 {
+		return 0, errors.New("final amplifier did not output a signal")
+	}
+	return last.comp.LastOutput(), nil
+}
+
+// Top tries every setting chosen by the strategy, returning the k settings with the highest signal in
+// descending order, or every setting if k is zero. The first error raised by a setting stops the search.
+func (p *PhaseSearch) Top(k int) ([]PhaseResult, error) {
+	return p.TopContext(context.Background(), k)
+}
+
+// TopContext searches like Top, stopping when the context is done
+func (p *PhaseSearch) TopContext(ctx context.Context, k int) ([]PhaseResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	strategy := p.Strategy
+	if strategy == nil {
+		strategy = Permutations{}
+	}
+	workers := p.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	settings := make(chan []int)
+	go func() {
+		defer close(settings)
+		strategy.Settings(p.Amplifiers, p.Phases, func(setting []int) bool {
+			select {
+			case settings <- append([]int(nil), setting...):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var top []PhaseResult
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for setting := range settings {
+				signal, err := p.signal(ctx, setting)
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = errors.Wrapf(err, "phases %v", setting)
+					}
+					cancel()
+				} else {
+					top = insertResult(top, PhaseResult{Phases: setting, Signal: signal}, k)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return top, nil
+}
+
+// insertResult adds a result to a list sorted by descending signal, keeping at most k results if k is not
+// zero. Equal signals are ordered by phases so the results do not depend on which worker finished first.
+func insertResult(results []PhaseResult, r PhaseResult, k int) []PhaseResult {
+	i := sort.Search(len(results), func(i int) bool {
+		if results[i].Signal != r.Signal {
+			return results[i].Signal < r.Signal
+		}
+		return !phasesLess(results[i].Phases, r.Phases)
+	})
+	if k > 0 && i >= k {
+		return results
+	}
+	results = append(results, PhaseResult{})
+	copy(results[i+1:], results[i:])
+	results[i] = r
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+func phasesLess(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// ampLabel names amplifiers A to Z, then by number
+func ampLabel(i int) string {
+	if i < 26 {
+		return fmt.Sprintf("AMP %c", 'A'+i)
+	}
+	return fmt.Sprintf("AMP %d", i+1)
+}
@@ -0,0 +1,102 @@
+package intcode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseSearch(t *testing.T) {
+	tt := map[string]struct {
+		inputData string
+		phases    []int
+		feedback  bool
+		expPhases []int
+		expSignal int64
+	}{
+		"example 1":          {inputData: "3,15,3,16,1002,16,10,16,1,16,15,15,4,15,99,0,0", phases: []int{0, 1, 2, 3, 4}, expPhases: []int{4, 3, 2, 1, 0}, expSignal: 43210},
+		"example 2":          {inputData: "3,23,3,24,1002,24,10,24,1002,23,-1,23,101,5,23,23,1,24,23,23,4,23,99,0,0", phases: []int{0, 1, 2, 3, 4}, expPhases: []int{0, 1, 2, 3, 4}, expSignal: 54321},
+		"feedback example 1": {inputData: "3,26,1001,26,-4,26,3,27,1002,27,2,27,1,27,26,27,4,27,1001,28,-1,28,1005,28,6,99,0,0,5", phases: []int{5, 6, 7, 8, 9}, feedback: true, expPhases: []int{9, 8, 7, 6, 5}, expSignal: 139629729},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			search, err := NewPhaseSearch(tc.inputData)
+			require.NoError(t, err)
+			search.Phases = tc.phases
+			search.Feedback = tc.feedback
+
+			top, err := search.Top(1)
+			require.NoError(t, err)
+			assert.Equal(t, []PhaseResult{{Phases: tc.expPhases, Signal: tc.expSignal}}, top)
+		})
+	}
+}
+
+func TestPhaseSearchTopK(t *testing.T) {
+	// Outputs the input signal times ten plus the phase
+	search, err := NewPhaseSearch("3,15,3,16,1002,16,10,16,1,15,16,16,4,16,99,0,0")
+	require.NoError(t, err)
+	search.Amplifiers = 3
+	search.Phases = []int{1, 2, 3, 4}
+	search.Workers = 3
+
+	all, err := search.Top(0)
+	require.NoError(t, err)
+	assert.Len(t, all, 24)
+
+	top, err := search.Top(3)
+	require.NoError(t, err)
+	assert.Equal(t, []PhaseResult{
+		{Phases: []int{4, 3, 2}, Signal: 432},
+		{Phases: []int{4, 3, 1}, Signal: 431},
+		{Phases: []int{4, 2, 3}, Signal: 423},
+	}, top)
+
+	search.Strategy = Sequences{}
+	all, err = search.Top(0)
+	require.NoError(t, err)
+	assert.Len(t, all, 64)
+	assert.Equal(t, PhaseResult{Phases: []int{4, 4, 4}, Signal: 444}, all[0])
+	assert.Equal(t, PhaseResult{Phases: []int{1, 1, 1}, Signal: 111}, all[63])
+}
+
+func TestPhaseStrategies(t *testing.T) {
+	var settings [][]int
+	collect := func(setting []int) bool {
+		settings = append(settings, append([]int(nil), setting...))
+		return len(settings) < 4
+	}
+
+	Permutations{}.Settings(2, []int{0, 1, 2}, collect)
+	assert.Equal(t, [][]int{{0, 1}, {0, 2}, {1, 0}, {1, 2}}, settings)
+
+	settings = nil
+	Sequences{}.Settings(2, []int{0, 1}, collect)
+	assert.Equal(t, [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}, settings)
+}
+
+func TestPhaseSearchErrors(t *testing.T) {
+	search, err := NewPhaseSearch("3,0,99")
+	require.NoError(t, err)
+	search.Amplifiers = 2
+	search.Phases = []int{0, 1}
+
+	_, err = search.Signal([]int{0})
+	assert.EqualError(t, err, "incorrect number of phases provided (got 1, want 2)")
+
+	_, err = search.Top(1)
+	assert.EqualError(t, err, "phases [0 1]: final amplifier did not output a signal")
+
+	search, err = NewPhaseSearch("3,9,3,9,3,9,4,9,99,0")
+	require.NoError(t, err)
+	_, err = search.Top(1)
+	assert.ErrorIs(t, err, ErrDeadlock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = search.TopContext(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,497 @@
+package intcode
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// EdgeKind is how control passes from one basic block to another
+type EdgeKind int
+
+const (
+	// EdgeNext continues to the block following the last instruction
+	EdgeNext EdgeKind = iota
+	// EdgeJump is a jump to a constant address
+	EdgeJump
+	// EdgeCall is a jump to a function after pushing a return address
+	EdgeCall
+	// EdgeReturn links a call to the return address it pushed, where the function called comes back to
+	EdgeReturn
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case EdgeNext:
+		return "next"
+	case EdgeJump:
+		return "jump"
+	case EdgeCall:
+		return "call"
+	case EdgeReturn:
+		return "return"
+	}
+	return fmt.Sprintf("EdgeKind(%d)", int(k))
+}
+
+// Edge is a transfer of control to the block starting at To
+type Edge struct {
+	To   int
+	Kind EdgeKind
+}
+
+// Block is a basic block, a run of instructions which is only entered at the first and only left after the
+// last
+type Block struct {
+	Start int
+	// End is the address following the last instruction
+	End   int
+	Lines []ListingLine
+	Edges []Edge
+	// Indirect is set when the block ends with a jump to a computed address
+	Indirect bool
+	// Invalid is set when the block ends at an instruction which can not be decoded
+	Invalid bool
+}
+
+// Function is the blocks reached from a function entry without following calls, address 0 is the entry of
+// the main program
+type Function struct {
+	Entry int
+	// Frame is the relative base shift at the start of the entry block, zero if there is none
+	Frame  int64
+	Blocks []int
+	// Calls lists the entries of the functions called
+	Calls []int
+	// Returns is set if the function ends with a jump through the relative base, the return idiom
+	Returns bool
+}
+
+// MemoryAccess is an instruction accessing a constant address
+type MemoryAccess struct {
+	Addr   int
+	Target int
+}
+
+// Analysis is the control flow graph of a program and the problems found in it
+type Analysis struct {
+	ProgramSize int
+	// Blocks lists the basic blocks by start address
+	Blocks    []*Block
+	Functions []*Function
+	// SelfModifying lists writes to the cells of reachable instructions
+	SelfModifying []MemoryAccess
+	// Unreachable lists the ranges of the program which are neither reachable code nor accessed at a
+	// constant address
+	Unreachable []AddrRange
+	// UninitializedReads lists reads beyond the end of the program of cells which are never written at a
+	// constant address, so are always zero unless written through the relative base
+	UninitializedReads []MemoryAccess
+}
+
+// flowOp is a decoded instruction reached by following control flow
+type flowOp struct {
+	addr     int
+	size     int
+	mnemonic string
+	params   []param
+	// invalid instructions are kept so they can be reported when reached
+	invalid bool
+}
+
+// Analyze builds the control flow graph of a program from address 0, following the same successors as
+// DisassembleReachable. Functions are found from the call idiom, pushing a constant return address onto
+// the relative base stack before a jump, and are expected to return by jumping through the relative base.
+// Only params at constant addresses are checked for self-modifying writes and uninitialized reads.
+func Analyze(inputData string) (*Analysis, error) {
+	c, err := NewComputer(inputData, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Analysis{ProgramSize: c.memory.len()}
+	ops := reachableOps(c)
+	starts := leaders(c, ops)
+	isLeader := make(map[int]bool)
+	for _, addr := range starts {
+		isLeader[addr] = true
+	}
+	blocks := make(map[int]*Block)
+	for _, start := range starts {
+		if b := buildBlock(c, ops, isLeader, start); b != nil {
+			blocks[start] = b
+			a.Blocks = append(a.Blocks, b)
+		}
+	}
+	a.findFunctions(blocks, ops)
+	a.checkMemory(ops)
+	return a, nil
+}
+
+// reachableOps decodes every instruction reachable from address 0, following the same successors as
+// DisassembleReachable but keeping invalid instructions
+func reachableOps(c *Computer) map[int]*flowOp {
+	ops := make(map[int]*flowOp)
+	toVisit := []int{0}
+	for len(toVisit) != 0 {
+		addr := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if _, ok := ops[addr]; ok {
+			continue
+		}
+
+		ins, size, err := decodeAt(c, addr)
+		if err != nil {
+			ops[addr] = &flowOp{addr: addr, invalid: true}
+			continue
+		}
+		mnemonic, params := describe(ins)
+		ops[addr] = &flowOp{addr: addr, size: size, mnemonic: mnemonic, params: params}
+		toVisit = append(toVisit, successors(c, addr, size)...)
+	}
+	return ops
+}
+
+// leaders finds the address of the first instruction of every basic block, those jumped to, following a
+// jump or pushed as a return address
+func leaders(c *Computer, ops map[int]*flowOp) []int {
+	isLeader := map[int]bool{0: true}
+	for addr, op := range ops {
+		if op.invalid {
+			continue
+		}
+		next := addr + op.size
+		isJump := op.mnemonic == "JT" || op.mnemonic == "JF"
+		for _, s := range successors(c, addr, op.size) {
+			if isJump || s != next {
+				isLeader[s] = true
+			}
+		}
+	}
+	var result []int
+	for addr := range isLeader {
+		result = append(result, addr)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// buildBlock collects the instructions of the basic block starting at the address and the edges leaving it
+func buildBlock(c *Computer, ops map[int]*flowOp, isLeader map[int]bool, start int) *Block {
+	b := &Block{Start: start, End: start}
+	var prev *flowOp
+	for {
+		op, ok := ops[b.End]
+		if !ok {
+			break
+		}
+		if op.invalid {
+			b.Lines = append(b.Lines, ListingLine{Addr: op.addr, Mnemonic: MnemonicData, Raw: []int64{c.memory.get(op.addr)}})
+			b.End++
+			b.Invalid = true
+			return b
+		}
+		b.Lines = append(b.Lines, op.line(c))
+		b.End += op.size
+		switch op.mnemonic {
+		case "HALT":
+			return b
+		case "JT", "JF":
+			b.addJumpEdges(c, prev, op)
+			return b
+		}
+		if isLeader[b.End] {
+			b.Edges = append(b.Edges, Edge{To: b.End, Kind: EdgeNext})
+			return b
+		}
+		prev = op
+	}
+	if len(b.Lines) == 0 {
+		return nil
+	}
+	return b
+}
+
+// addJumpEdges adds the edges leaving a block which ends with a jump, prev is the instruction before the
+// jump within the block if there is one
+func (b *Block) addJumpEdges(c *Computer, prev, op *flowOp) {
+	cond, target := op.params[0], op.params[1]
+	taken := (cond.val != 0) == (op.mnemonic == "JT")
+	if cond.mode != AbsoluteMode || !taken {
+		b.Edges = append(b.Edges, Edge{To: b.End, Kind: EdgeNext})
+	}
+	if cond.mode == AbsoluteMode && !taken {
+		return
+	}
+	if target.mode != AbsoluteMode {
+		b.Indirect = true
+		return
+	}
+	if prev != nil && (prev.mnemonic == "ADD" || prev.mnemonic == "MULT") && len(successors(c, prev.addr, prev.size)) > 1 {
+		// the return address pushed before the jump is where the call continues
+		b.Edges = append(b.Edges, Edge{To: int(target.val), Kind: EdgeCall}, Edge{To: b.End, Kind: EdgeReturn})
+		return
+	}
+	b.Edges = append(b.Edges, Edge{To: int(target.val), Kind: EdgeJump})
+}
+
+// line gives the listing line of a valid instruction
+func (op *flowOp) line(c *Computer) ListingLine {
+	line := ListingLine{Addr: op.addr, Mnemonic: op.mnemonic, Raw: make([]int64, op.size)}
+	for i := range line.Raw {
+		line.Raw[i] = c.memory.get(op.addr + i)
+	}
+	for _, p := range op.params {
+		line.Operands = append(line.Operands, formatParam(p))
+	}
+	return line
+}
+
+// findFunctions groups the blocks into functions, starting from address 0 and every called address
+func (a *Analysis) findFunctions(blocks map[int]*Block, ops map[int]*flowOp) {
+	entries := map[int]bool{0: true}
+	for _, b := range a.Blocks {
+		for _, e := range b.Edges {
+			if e.Kind == EdgeCall {
+				entries[e.To] = true
+			}
+		}
+	}
+	var sorted []int
+	for entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Ints(sorted)
+
+	for _, entry := range sorted {
+		f := &Function{Entry: entry}
+		if op := ops[entry]; op.mnemonic == "ARB" && op.params[0].mode == AbsoluteMode {
+			f.Frame = op.params[0].val
+		}
+		visited := make(map[int]bool)
+		calls := make(map[int]bool)
+		toVisit := []int{entry}
+		for len(toVisit) != 0 {
+			start := toVisit[len(toVisit)-1]
+			toVisit = toVisit[:len(toVisit)-1]
+			b, ok := blocks[start]
+			if !ok || visited[start] {
+				continue
+			}
+			visited[start] = true
+			f.Blocks = append(f.Blocks, start)
+			if b.Indirect && ops[b.Lines[len(b.Lines)-1].Addr].params[1].mode == RelativeMode {
+				f.Returns = true
+			}
+			for _, e := range b.Edges {
+				if e.Kind == EdgeCall {
+					calls[e.To] = true
+					continue
+				}
+				toVisit = append(toVisit, e.To)
+			}
+		}
+		sort.Ints(f.Blocks)
+		for addr := range calls {
+			f.Calls = append(f.Calls, addr)
+		}
+		sort.Ints(f.Calls)
+		a.Functions = append(a.Functions, f)
+	}
+}
+
+// checkMemory finds writes to code, unreachable ranges and uninitialized reads from the params of the
+// reachable instructions
+func (a *Analysis) checkMemory(ops map[int]*flowOp) {
+	code := make(map[int]bool)
+	for addr, op := range ops {
+		for i := 0; i < op.size; i++ {
+			code[addr+i] = true
+		}
+		if op.invalid {
+			code[addr] = true
+		}
+	}
+
+	accessed := make(map[int]bool)
+	written := make(map[int]bool)
+	var reads []MemoryAccess
+	for addr, op := range ops {
+		writeParam := opSpecs[op.mnemonic].writeParam
+		for i, p := range op.params {
+			// immediate mode writes are to the address given, as with position mode
+			if p.mode == RelativeMode || p.val < 0 || (p.mode == AbsoluteMode && (op.invalid || i != writeParam)) {
+				continue
+			}
+			target := int(p.val)
+			accessed[target] = true
+			if i != writeParam {
+				reads = append(reads, MemoryAccess{Addr: addr, Target: target})
+				continue
+			}
+			written[target] = true
+			if code[target] {
+				a.SelfModifying = append(a.SelfModifying, MemoryAccess{Addr: addr, Target: target})
+			}
+		}
+	}
+	for _, r := range reads {
+		if r.Target >= a.ProgramSize && !written[r.Target] {
+			a.UninitializedReads = append(a.UninitializedReads, r)
+		}
+	}
+	sortAccesses(a.SelfModifying)
+	sortAccesses(a.UninitializedReads)
+
+	for addr := 0; addr < a.ProgramSize; addr++ {
+		if code[addr] || accessed[addr] {
+			continue
+		}
+		if n := len(a.Unreachable); n != 0 && a.Unreachable[n-1].End == addr-1 {
+			a.Unreachable[n-1].End = addr
+			continue
+		}
+		a.Unreachable = append(a.Unreachable, AddrRange{Start: addr, End: addr})
+	}
+}
+
+// sortAccesses orders accesses by instruction then target address
+func sortAccesses(accesses []MemoryAccess) {
+	sort.Slice(accesses, func(i, j int) bool {
+		if accesses[i].Addr != accesses[j].Addr {
+			return accesses[i].Addr < accesses[j].Addr
+		}
+		return accesses[i].Target < accesses[j].Target
+	})
+}
+
+// Instructions is the number of reachable instructions
+func (a *Analysis) Instructions() int {
+	count := 0
+	for _, b := range a.Blocks {
+		count += len(b.Lines)
+	}
+	return count
+}
+
+// line finds the listing line of the reachable instruction at the address
+func (a *Analysis) line(addr int) ListingLine {
+	i := sort.Search(len(a.Blocks), func(i int) bool { return a.Blocks[i].End > addr })
+	if i < len(a.Blocks) {
+		for _, l := range a.Blocks[i].Lines {
+			if l.Addr == addr {
+				return l
+			}
+		}
+	}
+	return ListingLine{Addr: addr, Mnemonic: "????"}
+}
+
+// WriteReport writes a summary of the analysis
+func (a *Analysis) WriteReport(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "program size: %d\n", a.ProgramSize)
+	fmt.Fprintf(&sb, "reachable instructions: %d in %d blocks\n", a.Instructions(), len(a.Blocks))
+
+	sb.WriteString("\nfunctions:\n")
+	for _, f := range a.Functions {
+		fmt.Fprintf(&sb, "  %6d %4d blocks", f.Entry, len(f.Blocks))
+		if f.Frame != 0 {
+			fmt.Fprintf(&sb, ", frame %d", f.Frame)
+		}
+		if len(f.Calls) != 0 {
+			calls := make([]string, len(f.Calls))
+			for i, addr := range f.Calls {
+				calls[i] = fmt.Sprint(addr)
+			}
+			fmt.Fprintf(&sb, ", calls %s", strings.Join(calls, " "))
+		}
+		if f.Returns {
+			sb.WriteString(", returns")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nindirect jumps:\n")
+	for _, b := range a.Blocks {
+		if b.Indirect {
+			fmt.Fprintf(&sb, "  %s\n", b.Lines[len(b.Lines)-1])
+		}
+	}
+
+	sb.WriteString("\ninvalid instructions:\n")
+	for _, b := range a.Blocks {
+		if b.Invalid {
+			fmt.Fprintf(&sb, "  %s\n", b.Lines[len(b.Lines)-1])
+		}
+	}
+
+	sb.WriteString("\nself-modifying writes:\n")
+	for _, m := range a.SelfModifying {
+		fmt.Fprintf(&sb, "  %s -> %d\n", a.line(m.Addr), m.Target)
+	}
+
+	sb.WriteString("\nuninitialized reads:\n")
+	for _, r := range a.UninitializedReads {
+		fmt.Fprintf(&sb, "  %s -> %d\n", a.line(r.Addr), r.Target)
+	}
+
+	sb.WriteString("\nunreachable:\n")
+	var ranges []string
+	for _, r := range a.Unreachable {
+		ranges = append(ranges, r.String())
+	}
+	fmt.Fprintf(&sb, "  %s\n", strings.Join(ranges, ", "))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteDOT writes the control flow graph in Graphviz DOT format, with a node per basic block. Function
+// entries are drawn bold, calls dashed and the return edges of calls dotted.
+func (a *Analysis) WriteDOT(w io.Writer) error {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	entries := make(map[int]bool)
+	for _, f := range a.Functions {
+		entries[f.Entry] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph intcode {\n")
+	sb.WriteString("  node [shape=box, fontname=\"monospace\"];\n")
+	for _, b := range a.Blocks {
+		var label strings.Builder
+		for _, l := range b.Lines {
+			label.WriteString(escape.Replace(strings.TrimSpace(l.String())))
+			label.WriteString(`\l`)
+		}
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf("label=\"%s\"", label.String()))
+		if entries[b.Start] {
+			attrs = append(attrs, "style=bold")
+		}
+		if b.Invalid {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&sb, "  b%d [%s];\n", b.Start, strings.Join(attrs, ", "))
+	}
+	for _, b := range a.Blocks {
+		for _, e := range b.Edges {
+			switch e.Kind {
+			case EdgeNext:
+				fmt.Fprintf(&sb, "  b%d -> b%d;\n", b.Start, e.To)
+			case EdgeCall:
+				fmt.Fprintf(&sb, "  b%d -> b%d [label=\"call\", style=dashed];\n", b.Start, e.To)
+			case EdgeReturn:
+				fmt.Fprintf(&sb, "  b%d -> b%d [style=dotted];\n", b.Start, e.To)
+			default:
+				fmt.Fprintf(&sb, "  b%d -> b%d [label=\"%s\"];\n", b.Start, e.To, e.Kind)
+			}
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
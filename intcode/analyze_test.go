@@ -0,0 +1,117 @@
+package intcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callProgram calls a function at 12 which writes into the code of the caller, then outputs a cell beyond
+// the end of the program. Cells 10 and 11 are never used.
+const callProgram = "21101,0,7,0,1105,1,12,4,100,99,7,7,109,2,1101,1,1,8,109,-2,2106,0,0"
+
+func TestAnalyze(t *testing.T) {
+	a, err := Analyze(callProgram)
+	require.NoError(t, err)
+
+	assert.Equal(t, 23, a.ProgramSize)
+	assert.Equal(t, 8, a.Instructions())
+	var starts []int
+	for _, b := range a.Blocks {
+		starts = append(starts, b.Start)
+	}
+	assert.Equal(t, []int{0, 7, 12}, starts)
+	assert.Equal(t, []Edge{{To: 12, Kind: EdgeCall}, {To: 7, Kind: EdgeReturn}}, a.Blocks[0].Edges)
+	assert.Empty(t, a.Blocks[1].Edges)
+	assert.True(t, a.Blocks[2].Indirect)
+
+	assert.Equal(t, []*Function{
+		{Entry: 0, Blocks: []int{0, 7}, Calls: []int{12}},
+		{Entry: 12, Frame: 2, Blocks: []int{12}, Returns: true},
+	}, a.Functions)
+	assert.Equal(t, []MemoryAccess{{Addr: 14, Target: 8}}, a.SelfModifying)
+	assert.Equal(t, []MemoryAccess{{Addr: 7, Target: 100}}, a.UninitializedReads)
+	assert.Equal(t, []AddrRange{{Start: 10, End: 11}}, a.Unreachable)
+}
+
+func TestAnalyzeFindings(t *testing.T) {
+	tt := map[string]struct {
+		inputCode          string
+		selfModifying      []MemoryAccess
+		uninitializedReads []MemoryAccess
+		unreachable        []AddrRange
+		invalid            []int
+	}{
+		"written beyond program": {
+			inputCode:          "1101,1,2,50,4,50,4,51,99",
+			uninitializedReads: []MemoryAccess{{Addr: 6, Target: 51}},
+		},
+		"immediate write": {
+			inputCode:     "11101,1,2,0,99",
+			selfModifying: []MemoryAccess{{Addr: 0, Target: 0}},
+		},
+		"relative accesses": {inputCode: "109,50,204,1,21101,1,2,-9,99"},
+		"skipped data": {
+			inputCode:   "1105,1,5,42,43,99",
+			unreachable: []AddrRange{{Start: 3, End: 4}},
+		},
+		"invalid": {
+			inputCode:   "1106,0,4,99,42",
+			unreachable: []AddrRange{{Start: 3, End: 3}},
+			invalid:     []int{4},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			a, err := Analyze(tc.inputCode)
+			require.NoError(t, err)
+			var invalid []int
+			for _, b := range a.Blocks {
+				if b.Invalid {
+					invalid = append(invalid, b.Lines[len(b.Lines)-1].Addr)
+				}
+			}
+			assert.Equal(t, tc.selfModifying, a.SelfModifying)
+			assert.Equal(t, tc.uninitializedReads, a.UninitializedReads)
+			assert.Equal(t, tc.unreachable, a.Unreachable)
+			assert.Equal(t, tc.invalid, invalid)
+		})
+	}
+}
+
+func TestAnalyzeBoost(t *testing.T) {
+	a, err := Analyze(readProgram(t, "../day09/input.txt"))
+	require.NoError(t, err)
+
+	var entries []int
+	for _, f := range a.Functions {
+		entries = append(entries, f.Entry)
+	}
+	assert.Equal(t, []int{0, 922}, entries)
+	assert.Equal(t, []int{922}, a.Functions[1].Calls)
+	assert.True(t, a.Functions[1].Returns)
+	assert.Empty(t, a.SelfModifying)
+}
+
+func TestAnalysisOutput(t *testing.T) {
+	a, err := Analyze(callProgram)
+	require.NoError(t, err)
+
+	var report strings.Builder
+	require.NoError(t, a.WriteReport(&report))
+	assert.Contains(t, report.String(), "reachable instructions: 8 in 3 blocks\n")
+	assert.Contains(t, report.String(), "\nfunctions:\n       0    2 blocks, calls 12\n      12    1 blocks, frame 2, returns\n")
+	assert.Contains(t, report.String(), "\nself-modifying writes:\n      14: ADD  #1, #1, [8] -> 8\n")
+	assert.Contains(t, report.String(), "\nuninitialized reads:\n       7: OUT  [100] -> 100\n")
+	assert.Contains(t, report.String(), "\nunreachable:\n  10-11\n")
+
+	var dot strings.Builder
+	require.NoError(t, a.WriteDOT(&dot))
+	assert.True(t, strings.HasPrefix(dot.String(), "digraph intcode {\n"))
+	assert.Contains(t, dot.String(), `  b0 [label="0: ADD  #0, #7, rb+0\l4: JT   #1, #12\l", style=bold];`)
+	assert.Contains(t, dot.String(), "  b0 -> b12 [label=\"call\", style=dashed];\n")
+	assert.Contains(t, dot.String(), "  b0 -> b7 [style=dotted];\n")
+}
@@ -0,0 +1,127 @@
+package intcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxASCII is the largest output value which is a character, larger values are numeric results
+const maxASCII = 127
+
+// ASCII talks to a program which reads and writes lines of text one character per value, as used by the
+// ASCII-capable programs of the later puzzles. Output values outside the ASCII range are numeric results,
+// such as the amount of dust collected, rather than characters.
+type ASCII struct {
+	// Echo receives the text as it is output, with numeric results on lines of their own
+	Echo io.Writer
+
+	c       *Computer
+	partial strings.Builder
+	lines   []string
+	values  []int64
+}
+
+// NewASCII creates an adapter for the computer, which is run synchronously with Resume
+func NewASCII(c *Computer) *ASCII {
+	return &ASCII{c: c}
+}
+
+// Computer gives the computer being driven
+func (a *ASCII) Computer() *Computer {
+	return a.c
+}
+
+// SendLine queues the characters of the line and a newline to be read by the program
+func (a *ASCII) SendLine(line string) {
+	values := make([]int64, 0, len(line)+1)
+	for i := 0; i < len(line); i++ {
+		values = append(values, int64(line[i]))
+	}
+	a.c.SendInput(append(values, '\n')...)
+}
+
+// Resume runs the program until it halts or needs input which has not been sent, collecting its output.
+// The status is Halted or NeedsInput, or Failed along with an error.
+func (a *ASCII) Resume() (Status, error) {
+	for {
+		status, err := a.c.Resume()
+		if err != nil || status != HasOutput {
+			return status, err
+		}
+		a.receive(a.c.LastOutput())
+	}
+}
+
+// receive handles a single output value
+func (a *ASCII) receive(val int64) {
+	if val < 0 || val > maxASCII {
+		a.values = append(a.values, val)
+		if a.Echo != nil {
+			fmt.Fprintf(a.Echo, "%d\n", val)
+		}
+		return
+	}
+	if a.Echo != nil {
+		a.Echo.Write([]byte{byte(val)})
+	}
+	if val == '\n' {
+		a.lines = append(a.lines, a.partial.String())
+		a.partial.Reset()
+		return
+	}
+	a.partial.WriteByte(byte(val))
+}
+
+// ReadLines gives the complete lines output since it was last called, without their newlines
+func (a *ASCII) ReadLines() []string {
+	lines := a.lines
+	a.lines = nil
+	return lines
+}
+
+// Prompt gives the text output since the last newline, such as a prompt waiting for input
+func (a *ASCII) Prompt() string {
+	return a.partial.String()
+}
+
+// Values lists every numeric result output
+func (a *ASCII) Values() []int64 {
+	return a.values
+}
+
+// LastValue gives the most recent numeric result, reporting false if there has not been one
+func (a *ASCII) LastValue() (int64, bool) {
+	if len(a.values) == 0 {
+		return 0, false
+	}
+	return a.values[len(a.values)-1], true
+}
+
+// Interact runs the program as a terminal session, echoing its output to out and sending it a line read
+// from in whenever it needs input, until it halts. ErrInputClosed is returned if in ends while the program
+// is waiting.
+func (a *ASCII) Interact(in io.Reader, out io.Writer) error {
+	echo := a.Echo
+	a.Echo = out
+	defer func() { a.Echo = echo }()
+
+	reader := bufio.NewReader(in)
+	for {
+		status, err := a.Resume()
+		if err != nil || status == Halted {
+			return err
+		}
+		line, err := reader.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return ErrInputClosed
+		}
+		if err != nil && err != io.EOF {
+			return errors.Wrap(err, "unable to read input line")
+		}
+		a.SendLine(strings.TrimRight(line, "\r\n"))
+	}
+}
@@ -0,0 +1,62 @@
+package intcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// promptProgram outputs "OK", the result 1000 and a ">" prompt, then echoes a line of input
+const promptProgram = "104,79,104,75,104,10,104,1000,104,62,3,100,4,100,1008,100,10,101,1006,101,10,99"
+
+func TestASCII(t *testing.T) {
+	a := NewASCII(newEngineComputer(t, promptProgram, false))
+
+	status, err := a.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, NeedsInput, status)
+	assert.Equal(t, []string{"OK"}, a.ReadLines())
+	assert.Equal(t, ">", a.Prompt())
+	assert.Equal(t, []int64{1000}, a.Values())
+
+	a.SendLine("hi")
+	status, err = a.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, Halted, status)
+	assert.Equal(t, []string{">hi"}, a.ReadLines())
+	assert.Empty(t, a.ReadLines())
+	assert.Equal(t, "", a.Prompt())
+	val, ok := a.LastValue()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), val)
+}
+
+func TestASCIIInteract(t *testing.T) {
+	tt := map[string]struct {
+		input  string
+		output string
+		err    error
+	}{
+		"line":                {input: "hey\n", output: "OK\n1000\n>hey\n"},
+		"carriage return":     {input: "hey\r\n", output: "OK\n1000\n>hey\n"},
+		"no trailing newline": {input: "hey", output: "OK\n1000\n>hey\n"},
+		"no input":            {input: "", output: "OK\n1000\n>", err: ErrInputClosed},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			a := NewASCII(newEngineComputer(t, promptProgram, false))
+			var out strings.Builder
+			err := a.Interact(strings.NewReader(tc.input), &out)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.output, out.String())
+			assert.Nil(t, a.Echo)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package intcode
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// directiveData is the assembler directive for placing raw values in memory
+const directiveData = "DB"
+
+type opSpec struct {
+	code       OpCode
+	paramSize  int
+	writeParam int
+}
+
+// opSpecs lists the mnemonics understood by the assembler, matching those produced by the disassembler
+var opSpecs = map[string]opSpec{
+	"ADD":  {code: OpCodeAdd, paramSize: 3, writeParam: 2},
+	"MULT": {code: OpCodeMultiply, paramSize: 3, writeParam: 2},
+	"IN":   {code: OpCodeInput, paramSize: 1, writeParam: 0},
+	"OUT":  {code: OpCodeOutput, paramSize: 1, writeParam: -1},
+	"JT":   {code: OpCodeJumpIfTrue, paramSize: 2, writeParam: -1},
+	"JF":   {code: OpCodeJumpIfFalse, paramSize: 2, writeParam: -1},
+	"LT":   {code: OpCodeLessThan, paramSize: 3, writeParam: 2},
+	"EQ":   {code: OpCodeEqual, paramSize: 3, writeParam: 2},
+	"ARB":  {code: OpCodeShiftRelative, paramSize: 1, writeParam: -1},
+	"HALT": {code: OpCodeHalt, paramSize: 0, writeParam: -1},
+}
+
+var labelRegex = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*|[0-9]+)\s*:`)
+var identRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type asmLine struct {
+	num      int
+	addr     int
+	mnemonic string
+	operands []string
+}
+
+// Assemble converts assembly source into a program in the comma separated form read by NewComputer.
+//
+// Each line holds an optional label, a mnemonic or the DB directive, and its operands, with comments
+// starting at a semicolon:
+//
+//	loop: IN   [value]           ; position mode
+//	      ADD  [value], #-1, rb+2 ; immediate and relative mode
+//	      JT   #1, #loop
+//	value: DB  0, "text", 10
+//
+// Operand values are numbers or labels, optionally offset by a number (e.g. label+2). Numeric labels
+// (as printed by the disassembler) assert the address of the line rather than defining a label.
+func Assemble(source string) (string, error) {
+	lines, labels, err := parseAssembly(source)
+	if err != nil {
+		return "", err
+	}
+
+	var program []string
+	for _, l := range lines {
+		values, err := encodeLine(l, labels)
+		if err != nil {
+			return "", errors.Wrapf(err, "line %d", l.num)
+		}
+		for _, v := range values {
+			program = append(program, strconv.FormatInt(v, 10))
+		}
+	}
+	return strings.Join(program, ","), nil
+}
+
+// parseAssembly splits the source into lines and assigns every line and label an address
+func parseAssembly(source string) ([]asmLine, map[string]int64, error) {
+	labels := make(map[string]int64)
+	var lines []asmLine
+
+	addr := 0
+	for i, text := range strings.Split(source, "\n") {
+		num := i + 1
+		text = stripComment(text)
+
+		for {
+			match := labelRegex.FindStringSubmatch(text)
+			if match == nil {
+				break
+			}
+			text = text[len(match[0]):]
+			label := match[1]
+			if n, err := strconv.Atoi(label); err == nil {
+				if n != addr {
+					return nil, nil, errors.Errorf("line %d: address %d does not match assembled address %d", num, n, addr)
+				}
+				continue
+			}
+			if _, ok := labels[label]; ok {
+				return nil, nil, errors.Errorf("line %d: label %q already defined", num, label)
+			}
+			labels[label] = int64(addr)
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		mnemonic := strings.ToUpper(fields[0])
+		if mnemonic == MnemonicData {
+			// accept data lines from disassembler listings
+			mnemonic = directiveData
+		}
+		operands, err := splitOperands(strings.TrimSpace(text[strings.Index(text, fields[0])+len(fields[0]):]))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "line %d", num)
+		}
+		l := asmLine{num: num, addr: addr, mnemonic: mnemonic, operands: operands}
+
+		size, err := lineSize(l)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "line %d", num)
+		}
+		lines = append(lines, l)
+		addr += size
+	}
+	return lines, labels, nil
+}
+
+// lineSize is the number of memory values the line assembles to
+func lineSize(l asmLine) (int, error) {
+	if l.mnemonic == directiveData {
+		size := 0
+		for _, operand := range l.operands {
+			if strings.HasPrefix(operand, `"`) {
+				str, err := strconv.Unquote(operand)
+				if err != nil {
+					return 0, errors.Errorf("invalid string %s", operand)
+				}
+				size += len(str)
+				continue
+			}
+			size++
+		}
+		return size, nil
+	}
+
+	spec, ok := opSpecs[l.mnemonic]
+	if !ok {
+		return 0, errors.Errorf("unknown mnemonic %q", l.mnemonic)
+	}
+	if len(l.operands) != spec.paramSize {
+		return 0, errors.Errorf("%s takes %d operands, got %d", l.mnemonic, spec.paramSize, len(l.operands))
+	}
+	return spec.paramSize + 1, nil
+}
+
+// encodeLine produces the memory values for an instruction or data directive
+func encodeLine(l asmLine, labels map[string]int64) ([]int64, error) {
+	if l.mnemonic == directiveData {
+		var values []int64
+		for _, operand := range l.operands {
+			if strings.HasPrefix(operand, `"`) {
+				str, _ := strconv.Unquote(operand)
+				for _, b := range []byte(str) {
+					values = append(values, int64(b))
+				}
+				continue
+			}
+			v, err := resolveValue(operand, labels)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+
+	spec := opSpecs[l.mnemonic]
+	values := []int64{int64(spec.code)}
+	modeMultiplier := int64(100)
+	for i, operand := range l.operands {
+		mode, v, err := parseOperand(operand, labels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "operand %d", i+1)
+		}
+		if i == spec.writeParam && mode == AbsoluteMode {
+			return nil, errors.Errorf("operand %d of %s is written to and cannot be immediate", i+1, l.mnemonic)
+		}
+		values[0] += int64(mode) * modeMultiplier
+		modeMultiplier *= 10
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// parseOperand reads an operand written as [p] for position mode, #imm for immediate mode or rb+n for
+// relative mode
+func parseOperand(operand string, labels map[string]int64) (int, int64, error) {
+	switch {
+	case strings.HasPrefix(operand, "[") && strings.HasSuffix(operand, "]"):
+		v, err := resolveValue(strings.TrimSpace(operand[1:len(operand)-1]), labels)
+		return PostionMode, v, err
+	case strings.HasPrefix(operand, "#"):
+		v, err := resolveValue(strings.TrimSpace(operand[1:]), labels)
+		return AbsoluteMode, v, err
+	case strings.HasPrefix(strings.ToLower(operand), "rb"):
+		offset := strings.TrimSpace(operand[2:])
+		if offset == "" {
+			return RelativeMode, 0, nil
+		}
+		v, err := resolveValue(offset, labels)
+		return RelativeMode, v, err
+	default:
+		return 0, 0, errors.Errorf("invalid operand %q, expected [p], #imm or rb+n", operand)
+	}
+}
+
+// resolveValue reads a number, a label, or a label offset by a number
+func resolveValue(value string, labels map[string]int64) (int64, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseInt(strings.TrimPrefix(value, "+"), 10, 64); err == nil {
+		return n, nil
+	}
+
+	label, offset := value, ""
+	if i := strings.IndexAny(value, "+-"); i > 0 {
+		label, offset = strings.TrimSpace(value[:i]), value[i:]
+	}
+	if !identRegex.MatchString(label) {
+		return 0, errors.Errorf("invalid value %q", value)
+	}
+	addr, ok := labels[label]
+	if !ok {
+		return 0, errors.Errorf("undefined label %q", label)
+	}
+	if offset == "" {
+		return addr, nil
+	}
+	n, err := strconv.ParseInt(strings.Join(strings.Fields(offset), ""), 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid offset in %q", value)
+	}
+	return addr + n, nil
+}
+
+// stripComment removes anything after a semicolon which is not inside a string
+func stripComment(text string) string {
+	inString := false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && inString:
+			i++
+		case text[i] == '"':
+			inString = !inString
+		case text[i] == ';' && !inString:
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// splitOperands splits on commas which are not inside a string
+func splitOperands(text string) ([]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	var operands []string
+	inString := false
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && inString:
+			i++
+		case text[i] == '"':
+			inString = !inString
+		case text[i] == ',' && !inString:
+			operands = append(operands, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+	if inString {
+		return nil, errors.New("unterminated string")
+	}
+	operands = append(operands, strings.TrimSpace(text[start:]))
+	for _, operand := range operands {
+		if operand == "" {
+			return nil, errors.New("empty operand")
+		}
+	}
+	return operands, nil
+}
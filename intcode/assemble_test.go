@@ -0,0 +1,125 @@
+package intcode
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssemble(t *testing.T) {
+	tt := map[string]struct {
+		source    string
+		expOutput string
+	}{
+		"q example": {
+			source: `
+				ADD  [9], [10], [3]
+				MULT [3], [11], [0]
+				HALT
+				DB   30, 40, 50`,
+			expOutput: "1,9,10,3,2,3,11,0,99,30,40,50",
+		},
+		"modes": {
+			source: `
+				ARB  #-1
+				IN   rb+1
+				ADD  #4, #-5, rb+2
+				JT   #1, #0
+				HALT`,
+			expOutput: "109,-1,203,1,21101,4,-5,2,1105,1,0,99",
+		},
+		"labels": {
+			source: `
+			start:	IN   [value]        ; read into value
+					JF   [value], #end
+					OUT  [value+0]
+					JT   #1, #start
+			end:	HALT
+			value:	DB   0`,
+			expOutput: "3,11,1006,11,10,4,11,1105,1,0,99,0",
+		},
+		"strings and label data": {
+			source: `
+					OUT  [msg]
+					HALT
+			msg:	DB   "a,b;", 10, msg`,
+			expOutput: "4,3,99,97,44,98,59,10,3",
+		},
+		"disassembler listing": {
+			source: `
+				 0: OUT  #7
+				 2: HALT
+				 3: DATA 42, 0, 1, 1`,
+			expOutput: "104,7,99,42,0,1,1",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			program, err := Assemble(tc.source)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expOutput, program)
+		})
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tt := map[string]struct {
+		source string
+		expErr string
+	}{
+		"unknown mnemonic":  {source: "NOP", expErr: `line 1: unknown mnemonic "NOP"`},
+		"operand count":     {source: "\nADD #1, #2", expErr: "line 2: ADD takes 3 operands, got 2"},
+		"immediate write":   {source: "IN #1", expErr: "line 1: operand 1 of IN is written to and cannot be immediate"},
+		"undefined label":   {source: "JT #1, #nowhere", expErr: `line 1: operand 2: undefined label "nowhere"`},
+		"duplicate label":   {source: "a: HALT\na: HALT", expErr: `line 2: label "a" already defined`},
+		"bad operand":       {source: "OUT 5", expErr: `line 1: operand 1: invalid operand "5", expected [p], #imm or rb+n`},
+		"address mismatch":  {source: "0: HALT\n2: HALT", expErr: "line 2: address 2 does not match assembled address 1"},
+		"unterminated text": {source: `DB "abc`, expErr: "line 1: unterminated string"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := Assemble(tc.source)
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestAssembleAndRun(t *testing.T) {
+	// Counts down from the input, outputting each value
+	source := `
+			ARB  #stack
+			IN   rb+0
+	loop:	OUT  rb+0
+			ADD  rb+0, #-1, rb+0
+			JT   rb+0, #loop
+			HALT
+	stack:	DB   0`
+
+	program, err := Assemble(source)
+	require.NoError(t, err)
+
+	testComp, err := NewComputer(program, bufio.NewScanner(bytes.NewBufferString("3")))
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, []int64{3, 2, 1}, testComp.Outputs())
+}
+
+func TestDisassembleRoundTrip(t *testing.T) {
+	for _, program := range []string{"109,-1,203,1,21101,4,-5,2,1105,1,0,99,42,0,1,1", "1101,1,1,9,1099,199,1105,1,0,99"} {
+		listing, err := Disassemble(program)
+		require.NoError(t, err)
+
+		reassembled, err := Assemble(FormatListing(listing))
+		require.NoError(t, err)
+		assert.Equal(t, program, reassembled)
+	}
+}
@@ -0,0 +1,45 @@
+package intcode
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Assignment is a memory value to write before running, such as waking a program by writing 2 to address 0
+type Assignment struct {
+	Addr  int
+	Value int64
+}
+
+// ParseAssignments reads a comma separated list of addr=value pairs, blank entries are skipped
+func ParseAssignments(list string) ([]Assignment, error) {
+	var result []Assignment
+	for _, pair := range strings.Split(list, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid memory value %q, expected addr=value", pair)
+		}
+		addr, addrErr := strconv.Atoi(strings.TrimSpace(parts[0]))
+		val, valErr := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if addrErr != nil || valErr != nil {
+			return nil, errors.Errorf("invalid memory value %q, expected addr=value", pair)
+		}
+		result = append(result, Assignment{Addr: addr, Value: val})
+	}
+	return result, nil
+}
+
+// Assign writes each of the memory values in order
+func (c *Computer) Assign(assignments []Assignment) error {
+	for _, a := range assignments {
+		if err := c.WriteAddr(a.Addr, a.Value); err != nil {
+			return errors.Wrapf(err, "unable to set address %d", a.Addr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package intcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAssignments(t *testing.T) {
+	tt := map[string]struct {
+		list        string
+		assignments []Assignment
+		err         string
+	}{
+		"empty":       {list: ""},
+		"single":      {list: "0=2", assignments: []Assignment{{Addr: 0, Value: 2}}},
+		"several":     {list: " 1=12, 2=-2,,", assignments: []Assignment{{Addr: 1, Value: 12}, {Addr: 2, Value: -2}}},
+		"no value":    {list: "0", err: `invalid memory value "0", expected addr=value`},
+		"bad address": {list: "x=1", err: `invalid memory value "x=1", expected addr=value`},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assignments, err := ParseAssignments(tc.list)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.assignments, assignments)
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	testComp, err := NewComputer("1,0,0,0,99", nil)
+	require.NoError(t, err)
+	require.NoError(t, testComp.Assign([]Assignment{{Addr: 1, Value: 4}, {Addr: 2, Value: 4}}))
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, int64(198), testComp.ReadAddr(0))
+	assert.EqualError(t, testComp.Assign([]Assignment{{Addr: -1, Value: 1}}), "unable to set address -1: memory out of bounds: address -1")
+}
@@ -0,0 +1,235 @@
+package intcode
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Arithmetic selects how add and multiply handle results outside the int64 range
+type Arithmetic int
+
+const (
+	// WrapArithmetic wraps results around as int64 arithmetic does, this is the default
+	WrapArithmetic Arithmetic = iota
+	// CheckedArithmetic fails the instruction with an *OverflowError
+	CheckedArithmetic
+	// BigArithmetic stores results at full precision. Comparisons and output use the exact value, anywhere
+	// else a value outside the int64 range reads as the nearest int64.
+	BigArithmetic
+)
+
+func (a Arithmetic) String() string {
+	switch a {
+	case WrapArithmetic:
+		return "wrap"
+	case CheckedArithmetic:
+		return "checked"
+	case BigArithmetic:
+		return "big"
+	}
+	return "unknown"
+}
+
+// BigOutput is an Output which also accepts values outside the int64 range. A computer using BigArithmetic
+// fails with an *OverflowError when outputting such a value to any other Output.
+type BigOutput interface {
+	Output
+	WriteBigOutput(val *big.Int) error
+}
+
+// BigOutputFunc adapts a function to a BigOutput, receiving every value at full precision
+type BigOutputFunc func(val *big.Int) error
+
+// WriteOutput calls the function with the value
+func (f BigOutputFunc) WriteOutput(val int64) error {
+	return f(big.NewInt(val))
+}
+
+// WriteBigOutput calls the function with the value
+func (f BigOutputFunc) WriteBigOutput(val *big.Int) error {
+	return f(val)
+}
+
+// NewBigComputer reads in the input data in the form of a single CSV string, which may contain values of any
+// size, and creates a computer using BigArithmetic with the provided input and output
+func NewBigComputer(inputData string, in Input, out Output) (*Computer, error) {
+	memory, err := parseBigMemoryInput(inputData)
+	if err != nil {
+		return nil, err
+	}
+	return &Computer{Arithmetic: BigArithmetic, memory: memory, insPtr: 0, input: in, output: out}, nil
+}
+
+func parseBigMemoryInput(inputData string) (*memory, error) {
+	memory := newMemory(nil)
+	for i, memValStr := range strings.Split(strings.TrimSpace(inputData), ",") {
+		val, ok := new(big.Int).SetString(memValStr, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid value %q at %d", memValStr, i)
+		}
+		memory.setBig(i, val)
+	}
+	return memory, nil
+}
+
+// ReadBigAddr reads the memory value at an absolute address at full precision, addresses outside of memory
+// read as zero
+func (c *Computer) ReadBigAddr(addr int) *big.Int {
+	if c.addrOutOfBounds(addr) {
+		return new(big.Int)
+	}
+	if val := c.memory.getBig(addr); val != nil {
+		return new(big.Int).Set(val)
+	}
+	return big.NewInt(c.memory.get(addr))
+}
+
+// BigOutputs returns every value output by the computer so far at full precision, Outputs gives the nearest
+// int64 for values outside the int64 range
+func (c *Computer) BigOutputs() []*big.Int {
+	result := make([]*big.Int, len(c.outputs))
+	for i, out := range c.outputs {
+		if val, ok := c.bigOutputs[i]; ok {
+			result[i] = new(big.Int).Set(val)
+		} else {
+			result[i] = big.NewInt(out)
+		}
+	}
+	return result
+}
+
+// copyBigOutputs copies the record of outputs outside the int64 range, the values are never modified
+func copyBigOutputs(bigOutputs map[int]*big.Int) map[int]*big.Int {
+	if len(bigOutputs) == 0 {
+		return nil
+	}
+	cpy := make(map[int]*big.Int, len(bigOutputs))
+	for i, val := range bigOutputs {
+		cpy[i] = val
+	}
+	return cpy
+}
+
+// readBigMode reads a param like readMode, also giving the exact value if it is outside the int64 range
+func (c *Computer) readBigMode(p param) (int64, *big.Int, error) {
+	val, err := c.readMode(p)
+	if err != nil || p.mode == AbsoluteMode || !c.memory.hasBig() {
+		return val, nil, err
+	}
+	addr := int(p.val)
+	if p.mode == RelativeMode {
+		addr += c.relativeBase
+	}
+	return val, c.memory.getBig(addr), nil
+}
+
+// storeBigAtAddr stores a value of any size at the address referenced by the param
+func (c *Computer) storeBigAtAddr(p param, val *big.Int) error {
+	if val.IsInt64() {
+		return c.storeAtAddr(p, val.Int64())
+	}
+	if err := c.storeAtAddr(p, saturate(val)); err != nil {
+		return err
+	}
+	addr := int(p.val)
+	if p.mode == RelativeMode {
+		addr += c.relativeBase
+	}
+	c.memory.setBig(addr, val)
+	return nil
+}
+
+// writeBigOutput sends a value outside the int64 range to the computer's Output and records it in Outputs
+func (c *Computer) writeBigOutput(out *big.Int) error {
+	var err error
+	switch output := c.output.(type) {
+	case nil:
+	case BigOutput:
+		err = output.WriteBigOutput(out)
+	default:
+		err = &OverflowError{OpCode: OpCodeOutput, Operands: []*big.Int{out}}
+	}
+	if err != nil {
+		return err
+	}
+	if c.bigOutputs == nil {
+		c.bigOutputs = make(map[int]*big.Int)
+	}
+	c.bigOutputs[len(c.outputs)] = out
+	c.outputs = append(c.outputs, saturate(out))
+	c.logOutf("%sOUT : %d%s\n", Green, out, Reset)
+	return nil
+}
+
+// applyBig completes a binary op at full precision, used when an operand or the result is outside the int64
+// range
+func (b binaryOp) applyBig(c *Computer, x *big.Int, y *big.Int) error {
+	result := b.bigOperator(x, y)
+	if !result.IsInt64() {
+		switch c.Arithmetic {
+		case WrapArithmetic:
+			result = big.NewInt(wrapInt64(result))
+		case CheckedArithmetic:
+			return &OverflowError{OpCode: b.code, Operands: []*big.Int{x, y}}
+		}
+	}
+	if err := c.storeBigAtAddr(b.params[2], result); err != nil {
+		return err
+	}
+	c.logf(b.logFormat+"\n", x, y, result)
+	return nil
+}
+
+// overflows reports if the result of an op code on the operands is outside the int64 range
+func overflows(code OpCode, x int64, y int64) bool {
+	switch code {
+	case OpCodeAdd:
+		sum := x + y
+		return (x > 0 && y > 0 && sum < 0) || (x < 0 && y < 0 && sum >= 0)
+	case OpCodeMultiply:
+		if x == 0 || y == 0 {
+			return false
+		}
+		if (x == -1 && y == math.MinInt64) || (y == -1 && x == math.MinInt64) {
+			return true
+		}
+		return (x*y)/y != x
+	}
+	return false
+}
+
+func bigAdd(x, y *big.Int) *big.Int { return new(big.Int).Add(x, y) }
+
+func bigMultiply(x, y *big.Int) *big.Int { return new(big.Int).Mul(x, y) }
+
+func bigLessThan(x, y *big.Int) *big.Int { return big.NewInt(lessThan(int64(x.Cmp(y)), 0)) }
+
+func bigEqual(x, y *big.Int) *big.Int { return big.NewInt(equal(int64(x.Cmp(y)), 0)) }
+
+// bigOperand gives the exact value of an operand
+func bigOperand(val int64, exact *big.Int) *big.Int {
+	if exact != nil {
+		return exact
+	}
+	return big.NewInt(val)
+}
+
+// saturate gives the nearest int64 to the value
+func saturate(val *big.Int) int64 {
+	switch {
+	case val.IsInt64():
+		return val.Int64()
+	case val.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+// wrapInt64 gives the value wrapped around to the int64 range, as int64 arithmetic would
+func wrapInt64(val *big.Int) int64 {
+	return int64(new(big.Int).And(val, new(big.Int).SetUint64(math.MaxUint64)).Uint64())
+}
@@ -0,0 +1,160 @@
+package intcode
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	val, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok, s)
+	return val
+}
+
+func TestArithmeticModes(t *testing.T) {
+	// Adds the input to the largest int64 and outputs the result
+	program := "3,9,1001,9,9223372036854775807,10,4,10,99,0,0"
+
+	tt := map[string]struct {
+		arithmetic Arithmetic
+		fast       bool
+		input      int64
+		expOutput  string
+		expErr     string
+	}{
+		"wrap":             {arithmetic: WrapArithmetic, input: 1, expOutput: "-9223372036854775808"},
+		"checked":          {arithmetic: CheckedArithmetic, input: 1, expErr: "instruction at 2 (relative base 0): ADD of 1 and 9223372036854775807 overflows int64"},
+		"checked fast":     {arithmetic: CheckedArithmetic, fast: true, input: 1, expErr: "instruction at 2 (relative base 0): ADD of 1 and 9223372036854775807 overflows int64"},
+		"checked in range": {arithmetic: CheckedArithmetic, fast: true, input: -1, expOutput: "9223372036854775806"},
+		"big":              {arithmetic: BigArithmetic, input: 1, expOutput: "9223372036854775808"},
+		"big negative sum": {arithmetic: BigArithmetic, input: math.MinInt64, expOutput: "-1"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			testComp := newEngineComputer(t, program, tc.fast, tc.input)
+			testComp.Arithmetic = tc.arithmetic
+			err := testComp.Run()
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				assert.IsType(t, &OverflowError{}, errors.Cause(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, testComp.BigOutputs(), 1)
+			assert.Equal(t, tc.expOutput, testComp.BigOutputs()[0].String())
+		})
+	}
+}
+
+func TestOverflows(t *testing.T) {
+	tt := map[string]struct {
+		code OpCode
+		x, y int64
+		exp  bool
+	}{
+		"add in range":        {code: OpCodeAdd, x: math.MaxInt64, y: -1},
+		"add too large":       {code: OpCodeAdd, x: math.MaxInt64, y: 1, exp: true},
+		"add too small":       {code: OpCodeAdd, x: math.MinInt64, y: -1, exp: true},
+		"multiply in range":   {code: OpCodeMultiply, x: math.MinInt64, y: 1},
+		"multiply by zero":    {code: OpCodeMultiply, x: math.MaxInt64, y: 0},
+		"multiply too large":  {code: OpCodeMultiply, x: 1 << 32, y: 1 << 31, exp: true},
+		"multiply negate min": {code: OpCodeMultiply, x: -1, y: math.MinInt64, exp: true},
+		"compare":             {code: OpCodeLessThan, x: math.MaxInt64, y: math.MaxInt64},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, overflows(tc.code, tc.x, tc.y))
+		})
+	}
+}
+
+func TestBigComputer(t *testing.T) {
+	// Squares a value larger than int64, doubles the square, compares the two then outputs the double and the
+	// comparison
+	program := "1002,17,2,18,2,17,17,17,1,17,17,18,7,17,18,19,99,9223372036854775808,0,0"
+	var received []*big.Int
+	testComp, err := NewBigComputer(program, nil, BigOutputFunc(func(val *big.Int) error {
+		received = append(received, val)
+		return nil
+	}))
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+	testComp.Fast = true
+	require.NoError(t, testComp.Run())
+
+	square := bigInt(t, "85070591730234615865843651857942052864")
+	double := new(big.Int).Mul(square, big.NewInt(2))
+	assert.Equal(t, square, testComp.ReadBigAddr(17))
+	assert.Equal(t, double, testComp.ReadBigAddr(18))
+	assert.Equal(t, int64(math.MaxInt64), testComp.ReadAddr(18))
+	assert.Equal(t, big.NewInt(1), testComp.ReadBigAddr(19))
+	assert.Contains(t, testComp.DumpMemory(), ","+square.String()+","+double.String()+",1")
+	assert.Empty(t, received)
+
+	testComp, err = NewBigComputer("4,3,99,-9223372036854775809", nil, BigOutputFunc(func(val *big.Int) error {
+		received = append(received, val)
+		return nil
+	}))
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, []*big.Int{bigInt(t, "-9223372036854775809")}, received)
+	assert.Equal(t, []int64{math.MinInt64}, testComp.Outputs())
+	assert.Equal(t, received, testComp.BigOutputs())
+}
+
+func TestBigOutputRequiresBigOutput(t *testing.T) {
+	out := make(chan int64, 1)
+	testComp, err := NewBigComputer("4,3,99,18446744073709551616", nil, ChannelOutput(out))
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+
+	err = testComp.Run()
+	assert.EqualError(t, err, "instruction at 0 (relative base 0): OUT of 18446744073709551616 overflows int64")
+	assert.Empty(t, testComp.Outputs())
+}
+
+func TestBigComputerParseError(t *testing.T) {
+	_, err := NewBigComputer("1,2,x", nil, nil)
+	assert.EqualError(t, err, `invalid value "x" at 2`)
+}
+
+func TestBigStateIsKept(t *testing.T) {
+	// Doubles address 7 then outputs it
+	testComp, err := NewBigComputer("1002,7,2,7,4,7,99,9223372036854775807", nil, nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+	testComp.History = NewHistory(10)
+	before := testComp.ReadBigAddr(7)
+
+	require.NoError(t, testComp.Run())
+	doubled := bigInt(t, "18446744073709551614")
+	assert.Equal(t, doubled, testComp.ReadBigAddr(7))
+	assert.Equal(t, []*big.Int{doubled}, testComp.Clone().BigOutputs())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSnapshot(&buf, testComp.Snapshot()))
+	s, err := ReadSnapshot(&buf)
+	require.NoError(t, err)
+	restored, err := NewBigComputer("99", nil, nil)
+	require.NoError(t, err)
+	restored.Restore(s)
+	assert.Equal(t, doubled, restored.ReadBigAddr(7))
+	assert.Equal(t, []*big.Int{doubled}, restored.BigOutputs())
+
+	for testComp.History.Len() > 0 {
+		require.NoError(t, testComp.StepBack())
+	}
+	assert.Equal(t, before, testComp.ReadBigAddr(7))
+	assert.Empty(t, testComp.BigOutputs())
+}
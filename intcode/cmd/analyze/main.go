@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	dot := flag.String("dot", "", "file to write the control flow graph to in Graphviz DOT format")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 {
+		fmt.Println("usage: analyze [-dot file] program.txt")
+		os.Exit(1)
+	}
+	inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+	if err != nil {
+		fmt.Printf("unable to read input file, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	analysis, err := intcode.Analyze(string(inputBytes))
+	if err != nil {
+		fmt.Printf("unable to analyze program, %s\n", err.Error())
+		os.Exit(1)
+	}
+	analysis.WriteReport(os.Stdout)
+
+	if *dot == "" {
+		return
+	}
+	f, err := os.Create(*dot)
+	if err != nil {
+		fmt.Printf("unable to create DOT file, %s\n", err.Error())
+		os.Exit(1)
+	}
+	defer f.Close()
+	if err := analysis.WriteDOT(f); err != nil {
+		fmt.Printf("unable to write DOT file, %s\n", err.Error())
+		os.Exit(1)
+	}
+}
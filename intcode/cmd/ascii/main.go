@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	set := flag.String("set", "", "comma separated addr=value pairs to write to memory before running, such as 0=2")
+	script := flag.String("script", "", "file of lines to send before reading from stdin")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 {
+		fmt.Println("usage: ascii [-set addr=value,...] [-script file] program.txt")
+		os.Exit(1)
+	}
+	inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+	if err != nil {
+		fmt.Printf("unable to read input file, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	c, err := intcode.NewComputer(string(inputBytes), nil)
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	c.DisableLog = true
+	c.DisableOutLog = true
+	assignments, err := intcode.ParseAssignments(*set)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := c.Assign(assignments); err != nil {
+		fmt.Printf("unable to set memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	console := intcode.NewASCII(c)
+	if *script != "" {
+		scriptBytes, err := ioutil.ReadFile(*script)
+		if err != nil {
+			fmt.Printf("unable to read script file, %s\n", err.Error())
+			os.Exit(1)
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(scriptBytes), "\n"), "\n") {
+			console.SendLine(strings.TrimRight(line, "\r"))
+		}
+	}
+
+	if err := console.Interact(os.Stdin, os.Stdout); err != nil {
+		fmt.Printf("\nprogram stopped: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	var source []byte
+	var err error
+
+	if len(os.Args[1:]) == 1 {
+		source, err = ioutil.ReadFile(os.Args[1:][0])
+	} else {
+		source, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Printf("unable to read assembly source, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	program, err := intcode.Assemble(string(source))
+	if err != nil {
+		fmt.Printf("unable to assemble program, %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println(program)
+}
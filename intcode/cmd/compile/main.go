@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	pkg := flag.String("pkg", "main", "package of the generated file")
+	name := flag.String("name", "Program", "name of the generated function, run it with Computer.RunNative")
+	out := flag.String("o", "", "file to write the generated code to, stdout if not set")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 {
+		fmt.Println("usage: compile [-pkg package] [-name function] [-o file] program.txt")
+		os.Exit(1)
+	}
+	inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+	if err != nil {
+		fmt.Printf("unable to read input file, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	src, err := intcode.Compile(*pkg, intcode.NamedProgram{Name: *name, Code: string(inputBytes)})
+	if err != nil {
+		fmt.Printf("unable to compile program, %s\n", err.Error())
+		os.Exit(1)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		fmt.Printf("unable to write generated code, %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	reachable := flag.Bool("reachable", false, "only decode instructions reachable from address 0, listing everything else as data")
+	flag.Parse()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1024*1024)
+	var inputText string
+
+	if len(flag.Args()) == 1 {
+		inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+		if err != nil {
+			fmt.Printf("unable to read input file, %s\n", err.Error())
+			os.Exit(1)
+		}
+		inputText = string(inputBytes)
+	} else {
+		fmt.Println("ENTER INT CODE")
+		scanner.Scan()
+		inputText = scanner.Text()
+	}
+
+	disassemble := intcode.Disassemble
+	if *reachable {
+		disassemble = intcode.DisassembleReachable
+	}
+
+	listing, err := disassemble(inputText)
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Print(intcode.FormatListing(listing))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	runs := flag.Int("runs", 10000, "number of runs to make")
+	values := flag.String("values", "", "comma separated input values to favour, such as droid commands")
+	maxInputs := flag.Int("inputs", 16, "maximum length of an input sequence")
+	maxInstructions := flag.Int("max", 100000, "instruction budget of each run")
+	check := flag.Bool("check", false, "repeat every run with the fast engine and report differences")
+	seed := flag.Int64("seed", 1, "seed for the mutations")
+	corpus := flag.String("corpus", "", "file of input sequences, one comma separated sequence per line, to start from and save the corpus to")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 {
+		fmt.Println("usage: fuzz [-runs n] [-values v,...] [-inputs n] [-max n] [-check] [-seed n] [-corpus file] program.txt")
+		os.Exit(1)
+	}
+	inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+	if err != nil {
+		fmt.Printf("unable to read input file, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	f, err := intcode.NewFuzzer(string(inputBytes))
+	if err != nil {
+		fmt.Printf("unable to create fuzzer, %s\n", err.Error())
+		os.Exit(1)
+	}
+	favoured, err := parseValues(*values)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	f.Values = favoured
+	f.MaxInputs = *maxInputs
+	f.MaxInstructions = *maxInstructions
+	f.CheckEngines = *check
+	f.Rand = rand.New(rand.NewSource(*seed))
+
+	if *corpus != "" {
+		corpusBytes, err := ioutil.ReadFile(*corpus)
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Printf("unable to read corpus file, %s\n", err.Error())
+			os.Exit(1)
+		}
+		// the empty sequence is saved as a blank line, which cannot be told apart from padding, so start from it
+		// as a run without a corpus does
+		f.Seed(nil)
+		for _, line := range strings.Split(string(corpusBytes), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			inputs, err := parseValues(line)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			f.Seed(inputs)
+		}
+	}
+
+	f.Run(*runs)
+	f.WriteReport(os.Stdout)
+	if *corpus != "" {
+		writeCorpus(*corpus, f.Corpus())
+	}
+	if len(f.Crashes()) != 0 {
+		os.Exit(2)
+	}
+}
+
+// writeCorpus saves the input sequences one per line
+func writeCorpus(path string, corpus [][]int64) {
+	var sb strings.Builder
+	for _, inputs := range corpus {
+		values := make([]string, len(inputs))
+		for i, v := range inputs {
+			values[i] = strconv.FormatInt(v, 10)
+		}
+		sb.WriteString(strings.Join(values, ","))
+		sb.WriteString("\n")
+	}
+	if err := ioutil.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		fmt.Printf("unable to write corpus file, %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// parseValues reads a comma separated list of values
+func parseValues(list string) ([]int64, error) {
+	var result []int64
+	for _, v := range strings.Split(list, ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		val, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input value %q", v)
+		}
+		result = append(result, val)
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	top := flag.Int("top", 10, "number of entries to list in each section of the report")
+	inputs := flag.String("input", "", "comma separated values to provide as input")
+	maxInstructions := flag.Int("max", 0, "stop after this many instructions, zero for no limit")
+	flag.Parse()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1024*1024)
+	var inputText string
+
+	if len(flag.Args()) == 1 {
+		inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+		if err != nil {
+			fmt.Printf("unable to read input file, %s\n", err.Error())
+			os.Exit(1)
+		}
+		inputText = string(inputBytes)
+	} else {
+		fmt.Println("ENTER INT CODE")
+		scanner.Scan()
+		inputText = scanner.Text()
+	}
+
+	var inputValues []int64
+	for _, v := range strings.Split(*inputs, ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		val, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			fmt.Printf("invalid input value %q\n", v)
+			os.Exit(1)
+		}
+		inputValues = append(inputValues, val)
+	}
+
+	c, err := intcode.NewIOComputer(inputText, intcode.SliceInput(inputValues...), nil)
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	c.DisableLog = true
+	c.DisableOutLog = true
+	c.MaxInstructions = *maxInstructions
+	profiler := intcode.NewProfiler()
+	c.Profiler = profiler
+
+	// Report on however far the program got, even if it stopped with an error
+	err = c.Run()
+	fmt.Printf("outputs: %v\n", c.Outputs())
+	if err != nil {
+		fmt.Printf("program stopped: %s\n", err.Error())
+	}
+	fmt.Println()
+	profiler.WriteReport(os.Stdout, *top)
+}
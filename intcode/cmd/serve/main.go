@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	network := flag.String("network", "tcp", "network to listen on, tcp or unix")
+	addr := flag.String("addr", "localhost:4019", "address to listen on, a socket path for unix")
+	ascii := flag.Bool("ascii", false, "exchange lines of text rather than single values")
+	set := flag.String("set", "", "comma separated addr=value pairs to write to memory before running, such as 0=2")
+	flag.Parse()
+
+	if len(flag.Args()) != 1 {
+		fmt.Println("usage: serve [-network tcp|unix] [-addr address] [-ascii] [-set addr=value,...] program.txt")
+		os.Exit(1)
+	}
+	inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+	if err != nil {
+		fmt.Printf("unable to read input file, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	c, err := intcode.NewComputer(string(inputBytes), nil)
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	c.DisableLog = true
+	c.DisableOutLog = true
+	assignments, err := intcode.ParseAssignments(*set)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := c.Assign(assignments); err != nil {
+		fmt.Printf("unable to set memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	server := intcode.NewServer(c)
+	if *ascii {
+		server = intcode.NewASCIIServer(c)
+	}
+
+	l, err := net.Listen(*network, *addr)
+	if err != nil {
+		fmt.Printf("unable to listen, %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("serving %s on %s %s\n", flag.Args()[0], l.Addr().Network(), l.Addr())
+
+	err = server.Serve(l)
+	// closing the listener also removes a unix socket
+	l.Close()
+	if err != nil {
+		fmt.Printf("program stopped: %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println("program halted")
+}
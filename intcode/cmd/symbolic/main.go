@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"adventofcode/intcode"
+)
+
+func main() {
+	vars := flag.String("var", "", "comma separated addr=name pairs of memory cells holding variables, e.g. 1=noun,2=verb")
+	addrs := flag.String("addr", "0", "comma separated addresses to print the expression of once the program halts")
+	inputs := flag.String("input", "", "comma separated values to provide as input")
+	solve := flag.String("solve", "", "variable to solve for so the first address holds the wanted value")
+	want := flag.Int64("want", 0, "value wanted at the first address when solving")
+	with := flag.String("with", "", "comma separated name=value pairs of the other variables when solving")
+	maxInstructions := flag.Int("max", 0, "stop after this many instructions, zero for no limit")
+	flag.Parse()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1024*1024)
+	var inputText string
+
+	if len(flag.Args()) == 1 {
+		inputBytes, err := ioutil.ReadFile(flag.Args()[0])
+		if err != nil {
+			fmt.Printf("unable to read input file, %s\n", err.Error())
+			os.Exit(1)
+		}
+		inputText = string(inputBytes)
+	} else {
+		fmt.Println("ENTER INT CODE")
+		scanner.Scan()
+		inputText = scanner.Text()
+	}
+
+	s, err := intcode.NewSymbolic(strings.TrimSpace(inputText))
+	if err != nil {
+		fmt.Printf("unable to convert input to int code memory, %s\n", err.Error())
+		os.Exit(1)
+	}
+	s.MaxInstructions = *maxInstructions
+	for name, addr := range parsePairs(*vars, true) {
+		s.SetVar(int(addr), name)
+	}
+	for _, v := range splitList(*inputs) {
+		s.SendInput(parseInt(v))
+	}
+
+	if err := s.Run(); err != nil {
+		fmt.Printf("program stopped: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	var exprs []*intcode.Expr
+	for _, v := range splitList(*addrs) {
+		addr := parseInt(v)
+		expr := s.Expr(int(addr))
+		exprs = append(exprs, expr)
+		fmt.Printf("[%d] = %s\n", addr, expr)
+	}
+	for i, out := range s.Outputs() {
+		fmt.Printf("output %d = %s\n", i, out)
+	}
+
+	if *solve == "" || len(exprs) == 0 {
+		return
+	}
+	p, ok := exprs[0].Polynomial()
+	if !ok {
+		fmt.Printf("unable to solve, %s is not a polynomial\n", exprs[0])
+		os.Exit(1)
+	}
+	val, err := p.SolveFor(*solve, *want, parsePairs(*with, false))
+	if err != nil {
+		fmt.Printf("unable to solve for %s, %s\n", *solve, err.Error())
+		os.Exit(1)
+	}
+	fmt.Printf("%s = %d\n", *solve, val)
+}
+
+// splitList splits a comma separated flag, ignoring empty entries
+func splitList(list string) []string {
+	var values []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parsePairs parses a comma separated list of key=value pairs into a map by name, the name is the value of
+// each pair if nameSecond is set
+func parsePairs(list string, nameSecond bool) map[string]int64 {
+	pairs := map[string]int64{}
+	for _, pair := range splitList(list) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			fmt.Printf("invalid pair %q\n", pair)
+			os.Exit(1)
+		}
+		name, val := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if nameSecond {
+			name, val = val, name
+		}
+		pairs[name] = parseInt(val)
+	}
+	return pairs
+}
+
+func parseInt(v string) int64 {
+	val, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		fmt.Printf("invalid value %q\n", v)
+		os.Exit(1)
+	}
+	return val
+}
@@ -0,0 +1,300 @@
+package intcode
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// importPath is the import path of this package, used by generated code in other packages
+const importPath = "adventofcode/intcode"
+
+// NamedProgram is a program to compile into a function of the given name
+type NamedProgram struct {
+	Name string
+	Code string
+}
+
+// Compile translates programs to Go source for a file in the package, generating a NativeFunc for each
+// program to be run with RunNative. Instructions are decoded with readOp by following control flow from
+// address 0 as DisassembleReachable does. Each basic block becomes a case of a switch on the instruction
+// pointer, with jumps to constant addresses resolved at compile time. Programs which write to their own code
+// or jump to an address which was not reached are handed to the interpreter when they do so.
+func Compile(pkg string, programs ...NamedProgram) ([]byte, error) {
+	qualifier := "intcode."
+	if pkg == "intcode" {
+		qualifier = ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by intcode.Compile. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n", pkg)
+	if qualifier != "" {
+		fmt.Fprintf(&sb, "\nimport %q\n", importPath)
+	}
+	for _, p := range programs {
+		if !identRegex.MatchString(p.Name) {
+			return nil, errors.Errorf("invalid function name %q", p.Name)
+		}
+		c, err := NewComputer(p.Code, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to convert %s to int code memory", p.Name)
+		}
+		writeNativeFunc(&sb, qualifier, p.Name, c)
+	}
+
+	src, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to format generated code")
+	}
+	return src, nil
+}
+
+// nativeGen writes the Go code of a compiled program
+type nativeGen struct {
+	sb       *strings.Builder
+	ops      map[int]*flowOp
+	code     map[int]bool
+	isLeader map[int]bool
+}
+
+// writeNativeFunc writes a function running the compiled program
+func writeNativeFunc(sb *strings.Builder, qualifier, name string, c *Computer) {
+	g := &nativeGen{sb: sb, ops: reachableOps(c), code: make(map[int]bool), isLeader: make(map[int]bool)}
+	for addr, op := range g.ops {
+		for i := 0; i < op.size; i++ {
+			g.code[addr+i] = true
+		}
+	}
+	starts := leaders(c, g.ops)
+	for _, addr := range starts {
+		g.isLeader[addr] = true
+	}
+
+	fmt.Fprintf(sb, "\n// %s runs a compiled Intcode program of %d instructions\n", name, len(g.ops))
+	fmt.Fprintf(sb, "func %s(n *%sNative) error {\n", name, qualifier)
+	for _, span := range g.spans() {
+		fmt.Fprintf(sb, "if !n.Protect(%d, %d, %#x) {\nreturn n.Resume(n.Start(), 0)\n}\n", span[0], span[1], codeHash(c.memory, span[0], span[1]))
+	}
+	sb.WriteString("for pc := n.Start(); ; {\nswitch pc {\n")
+	for i, start := range starts {
+		nextCase := -1
+		if i+1 < len(starts) {
+			nextCase = starts[i+1]
+		}
+		g.writeBlock(start, nextCase)
+	}
+	sb.WriteString("default:\nreturn n.Resume(pc, 0)\n}\n}\n}\n")
+}
+
+// spans gives the ranges of addresses holding compiled code
+func (g *nativeGen) spans() [][2]int {
+	var addrs []int
+	for addr := range g.code {
+		addrs = append(addrs, addr)
+	}
+	sort.Ints(addrs)
+	var spans [][2]int
+	for _, addr := range addrs {
+		if len(spans) != 0 && spans[len(spans)-1][1] == addr {
+			spans[len(spans)-1][1]++
+			continue
+		}
+		spans = append(spans, [2]int{addr, addr + 1})
+	}
+	return spans
+}
+
+// writeBlock writes the case running the basic block starting at the address, every case ends by returning
+// or choosing the next block. Instructions are counted towards the budget as the block is entered, so
+// leaving part way through uncounts those which did not run.
+func (g *nativeGen) writeBlock(start, nextCase int) {
+	var block []*flowOp
+	for addr := start; ; {
+		op, ok := g.ops[addr]
+		if !ok {
+			break
+		}
+		block = append(block, op)
+		if op.invalid || op.mnemonic == "JT" || op.mnemonic == "JF" || op.mnemonic == "HALT" {
+			break
+		}
+		addr += op.size
+		if g.isLeader[addr] {
+			break
+		}
+	}
+	if len(block) == 0 {
+		return
+	}
+
+	fmt.Fprintf(g.sb, "case %d:\n", start)
+	fmt.Fprintf(g.sb, "if !n.Enter(%d) {\nreturn n.Resume(%d, 0)\n}\n", len(block), start)
+	for i, op := range block {
+		if !g.writeOp(op, len(block)-i) {
+			return
+		}
+	}
+	last := block[len(block)-1]
+	next := last.addr + last.size
+	if next == nextCase {
+		g.sb.WriteString("fallthrough\n")
+		return
+	}
+	fmt.Fprintf(g.sb, "pc = %d\ncontinue\n", next)
+}
+
+// writeOp writes the code of an instruction, remaining is the number of instructions of the block from this
+// one on. It reports false if the instruction always leaves the block.
+func (g *nativeGen) writeOp(op *flowOp, remaining int) bool {
+	if op.invalid {
+		fmt.Fprintf(g.sb, "// %d: invalid instruction\n", op.addr)
+		fmt.Fprintf(g.sb, "return n.Resume(%d, %d)\n", op.addr, remaining)
+		return false
+	}
+	operands := make([]string, len(op.params))
+	for i, p := range op.params {
+		operands[i] = formatParam(p)
+	}
+	fmt.Fprintf(g.sb, "// %d: %s\n", op.addr, strings.TrimSpace(op.mnemonic+" "+strings.Join(operands, ", ")))
+
+	// params accessed before the instruction has any effect are checked up front, the interpreter reports
+	// any that are out of bounds
+	checked := op.params
+	if op.mnemonic == "JT" || op.mnemonic == "JF" {
+		checked = op.params[:1]
+	}
+	if !g.writeBoundsCheck(op.addr, remaining, checked, opSpecs[op.mnemonic].writeParam) {
+		return false
+	}
+
+	next := op.addr + op.size
+	switch op.mnemonic {
+	case "ADD", "MULT", "LT", "EQ":
+		x, y := op.params[0], op.params[1]
+		var val string
+		switch {
+		case x.mode == AbsoluteMode && y.mode == AbsoluteMode:
+			operator := map[string]func(x, y int64) int64{"ADD": add, "MULT": multiply, "LT": lessThan, "EQ": equal}
+			val = fmt.Sprint(operator[op.mnemonic](x.val, y.val))
+		case op.mnemonic == "ADD":
+			val = fmt.Sprintf("%s + %s", g.read(x), g.read(y))
+		case op.mnemonic == "MULT":
+			val = fmt.Sprintf("%s * %s", g.read(x), g.read(y))
+		default:
+			comparison := "<"
+			if op.mnemonic == "EQ" {
+				comparison = "=="
+			}
+			val = fmt.Sprintf("v%d", op.addr)
+			fmt.Fprintf(g.sb, "%s := int64(0)\nif %s %s %s {\n%s = 1\n}\n", val, g.read(x), comparison, g.read(y), val)
+		}
+		return g.writeStore(op.params[2], val, next, remaining)
+	case "IN":
+		val := fmt.Sprintf("in%d", op.addr)
+		fmt.Fprintf(g.sb, "%s, err := n.Input(%d, %d)\nif err != nil {\nreturn err\n}\n", val, op.addr, remaining)
+		return g.writeStore(op.params[0], val, next, remaining)
+	case "OUT":
+		fmt.Fprintf(g.sb, "if err := n.Output(%d, %d, %s); err != nil {\nreturn err\n}\n", op.addr, remaining, g.read(op.params[0]))
+	case "ARB":
+		fmt.Fprintf(g.sb, "n.Shift(%s)\n", g.read(op.params[0]))
+	case "JT", "JF":
+		cond := op.params[0]
+		if cond.mode == AbsoluteMode {
+			if (cond.val != 0) != (op.mnemonic == "JT") {
+				return true
+			}
+			return g.writeJump(op, remaining)
+		}
+		comparison := "!="
+		if op.mnemonic == "JF" {
+			comparison = "=="
+		}
+		fmt.Fprintf(g.sb, "if %s %s 0 {\n", g.read(cond), comparison)
+		g.writeJump(op, remaining)
+		g.sb.WriteString("}\n")
+	case "HALT":
+		fmt.Fprintf(g.sb, "return n.Halt(%d)\n", op.addr)
+		return false
+	}
+	return true
+}
+
+// writeBoundsCheck hands the instruction to the interpreter if any of the params address negative memory,
+// reporting false if they always do. The written param is used as an address whatever its mode.
+func (g *nativeGen) writeBoundsCheck(addr, remaining int, params []param, writeParam int) bool {
+	var checks []string
+	for i, p := range params {
+		switch {
+		case p.mode == RelativeMode:
+			checks = append(checks, fmt.Sprintf("%s < 0", relativeAddr(p.val)))
+		case p.val < 0 && (p.mode == PostionMode || i == writeParam):
+			fmt.Fprintf(g.sb, "return n.Resume(%d, %d)\n", addr, remaining)
+			return false
+		}
+	}
+	if len(checks) != 0 {
+		fmt.Fprintf(g.sb, "if %s {\nreturn n.Resume(%d, %d)\n}\n", strings.Join(checks, " || "), addr, remaining)
+	}
+	return true
+}
+
+// writeJump writes a taken jump, reporting false as it always leaves the block
+func (g *nativeGen) writeJump(op *flowOp, remaining int) bool {
+	target := op.params[1]
+	switch {
+	case target.mode == AbsoluteMode && target.val >= 0:
+		fmt.Fprintf(g.sb, "pc = %d\ncontinue\n", target.val)
+		return false
+	case target.mode == AbsoluteMode:
+		fmt.Fprintf(g.sb, "return n.Resume(%d, %d)\n", op.addr, remaining)
+		return false
+	}
+	if !g.writeBoundsCheck(op.addr, remaining, op.params[1:], -1) {
+		return false
+	}
+	fmt.Fprintf(g.sb, "target := %s\nif target < 0 {\nreturn n.Resume(%d, %d)\n}\n", g.read(target), op.addr, remaining)
+	g.sb.WriteString("pc = int(target)\ncontinue\n")
+	return false
+}
+
+// writeStore writes a value to the address of a param. If it may be compiled code the rest of the program
+// is handed to the interpreter, reporting false if it always is.
+func (g *nativeGen) writeStore(p param, val string, next, remaining int) bool {
+	if p.mode == RelativeMode {
+		fmt.Fprintf(g.sb, "if n.Store(%s, %s) {\nreturn n.Resume(%d, %d)\n}\n", relativeAddr(p.val), val, next, remaining-1)
+		return true
+	}
+	// immediate mode writes are to the address given, as with position mode
+	fmt.Fprintf(g.sb, "n.Store(%d, %s)\n", p.val, val)
+	if g.code[int(p.val)] {
+		fmt.Fprintf(g.sb, "return n.Resume(%d, %d)\n", next, remaining-1)
+		return false
+	}
+	return true
+}
+
+// read gives an expression for the value of a param
+func (g *nativeGen) read(p param) string {
+	switch p.mode {
+	case AbsoluteMode:
+		return fmt.Sprint(p.val)
+	case RelativeMode:
+		return fmt.Sprintf("n.Load(%s)", relativeAddr(p.val))
+	}
+	return fmt.Sprintf("n.Load(%d)", p.val)
+}
+
+// relativeAddr gives an expression for an address relative to the relative base
+func relativeAddr(offset int64) string {
+	switch {
+	case offset > 0:
+		return fmt.Sprintf("n.Base()+%d", offset)
+	case offset < 0:
+		return fmt.Sprintf("n.Base()-%d", -offset)
+	}
+	return "n.Base()"
+}
@@ -0,0 +1,194 @@
+package intcode
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateNative = flag.Bool("update", false, "regenerate native_programs_test.go")
+
+const lessEqualGreater = "3,21,1008,21,8,20,1005,20,22,107,8,21,20,1006,20,31,1106,0,36,98,0,0,1002,21,125,20,4,20,1105,1,46,104,999,1105,1,46,1101,1000,1,20,4,20,1105,1,46,98,99"
+
+// nativeTestPrograms are compiled into native_programs_test.go, which is checked by
+// TestNativeProgramsUpToDate. Regenerate it with go test -run TestNativeProgramsUpToDate -update
+func nativeTestPrograms(tb testing.TB) []NamedProgram {
+	return []NamedProgram{
+		{Name: "nativeQExample", Code: "1,9,10,3,2,3,11,0,99,30,40,50"},
+		{Name: "nativeExample1", Code: "1,0,0,0,99"},
+		{Name: "nativeExample2", Code: "2,3,0,3,99"},
+		{Name: "nativeExample3", Code: "2,4,4,5,99,0"},
+		{Name: "nativeExample4", Code: "1,1,1,4,99,5,6,0,99"},
+		{Name: "nativeSimpleInput", Code: "3,3,99,0"},
+		{Name: "nativeImmediateAddition", Code: "1001,0,200,0,99"},
+		{Name: "nativeEcho", Code: "3,0,4,0,99"},
+		{Name: "nativePositionEqual", Code: "3,9,8,9,10,9,4,9,99,-1,8"},
+		{Name: "nativePositionLessThan", Code: "3,9,7,9,10,9,4,9,99,-1,8"},
+		{Name: "nativeAbsoluteEqual", Code: "3,3,1108,-1,8,3,4,3,99"},
+		{Name: "nativeAbsoluteLessThan", Code: "3,3,1107,-1,8,3,4,3,99"},
+		{Name: "nativePositionJump", Code: "3,12,6,12,15,1,13,14,13,4,13,99,-1,0,1,9"},
+		{Name: "nativeImmediateJump", Code: "3,3,1105,-1,9,1101,0,0,12,4,12,99,1"},
+		{Name: "nativeLessEqualGreater", Code: lessEqualGreater},
+		{Name: "nativeRelativeInput", Code: "109,-1,203,1,204,1,99"},
+		{Name: "nativeQuine", Code: "109,1,204,-1,1001,100,1,100,1008,100,16,101,1006,101,0,99"},
+		{Name: "nativeLargeMultiply", Code: "1102,34915192,34915192,7,4,7,99,0"},
+		{Name: "nativeLargeOutput", Code: "104,1125899906842624,99"},
+		{Name: "nativeWriteFar", Code: "1101,5,6,250000,4,250000,99"},
+		{Name: "nativeReadUntouched", Code: "4,1000000,99"},
+		{Name: "nativeRelativeWriteFar", Code: "109,500000,21101,2,3,7,204,7,99"},
+		{Name: "nativeUnknownOpCode", Code: "1,0,0,0,42"},
+		{Name: "nativeNegativePositionRead", Code: "1,-1,0,0,99"},
+		{Name: "nativeNegativeRelativeRead", Code: "109,2,204,-3,99"},
+		{Name: "nativeNegativeJumpTarget", Code: "1105,1,-3"},
+		{Name: "nativeInvalidMode", Code: "301,0,0,0,99"},
+		{Name: "nativeTooManyModes", Code: "10104,0,99"},
+		{Name: "nativeInputTwice", Code: "3,0,3,0,99"},
+		{Name: "nativeSelfModifying", Code: "104,0,1001,1,1,1,1007,1,3,20,1005,20,0,99"},
+		{Name: "nativeHaltWithMode", Code: "1101,1,1,5,199"},
+		{Name: "nativeGravityAssist", Code: readProgram(tb, "../day02/input.txt")},
+		{Name: "nativeBoost", Code: readProgram(tb, "../day09/input.txt")},
+	}
+}
+
+func TestNativeProgramsUpToDate(t *testing.T) {
+	src, err := Compile("intcode", nativeTestPrograms(t)...)
+	require.NoError(t, err)
+	if *updateNative {
+		require.NoError(t, ioutil.WriteFile("native_programs_test.go", src, 0644))
+	}
+	existing, err := ioutil.ReadFile("native_programs_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(src), string(existing), "compiled programs are out of date, rerun with -update")
+}
+
+func TestNativeMatchesInterpreter(t *testing.T) {
+	programs := map[string]string{}
+	for _, p := range nativeTestPrograms(t) {
+		programs[p.Name] = p.Code
+	}
+
+	tt := map[string]struct {
+		program string
+		fn      NativeFunc
+		inputs  []int64
+		writes  map[int]int64
+	}{
+		"q example":                {program: "nativeQExample", fn: nativeQExample},
+		"example 1":                {program: "nativeExample1", fn: nativeExample1},
+		"example 2":                {program: "nativeExample2", fn: nativeExample2},
+		"example 3":                {program: "nativeExample3", fn: nativeExample3},
+		"example 4":                {program: "nativeExample4", fn: nativeExample4},
+		"simple input":             {program: "nativeSimpleInput", fn: nativeSimpleInput, inputs: []int64{23}},
+		"immediate addition":       {program: "nativeImmediateAddition", fn: nativeImmediateAddition},
+		"echo":                     {program: "nativeEcho", fn: nativeEcho, inputs: []int64{254}},
+		"position equal false":     {program: "nativePositionEqual", fn: nativePositionEqual, inputs: []int64{5}},
+		"position equal true":      {program: "nativePositionEqual", fn: nativePositionEqual, inputs: []int64{8}},
+		"position less than":       {program: "nativePositionLessThan", fn: nativePositionLessThan, inputs: []int64{7}},
+		"position not less than":   {program: "nativePositionLessThan", fn: nativePositionLessThan, inputs: []int64{8}},
+		"absolute equal false":     {program: "nativeAbsoluteEqual", fn: nativeAbsoluteEqual, inputs: []int64{5}},
+		"absolute equal true":      {program: "nativeAbsoluteEqual", fn: nativeAbsoluteEqual, inputs: []int64{8}},
+		"absolute less than":       {program: "nativeAbsoluteLessThan", fn: nativeAbsoluteLessThan, inputs: []int64{7}},
+		"absolute not less than":   {program: "nativeAbsoluteLessThan", fn: nativeAbsoluteLessThan, inputs: []int64{23}},
+		"position jump (0)":        {program: "nativePositionJump", fn: nativePositionJump, inputs: []int64{0}},
+		"position jump (>1)":       {program: "nativePositionJump", fn: nativePositionJump, inputs: []int64{999}},
+		"immediate jump (0)":       {program: "nativeImmediateJump", fn: nativeImmediateJump, inputs: []int64{0}},
+		"immediate jump (>1)":      {program: "nativeImmediateJump", fn: nativeImmediateJump, inputs: []int64{999}},
+		"less equal greater (<)":   {program: "nativeLessEqualGreater", fn: nativeLessEqualGreater, inputs: []int64{3}},
+		"less equal greater (=)":   {program: "nativeLessEqualGreater", fn: nativeLessEqualGreater, inputs: []int64{8}},
+		"less equal greater (>)":   {program: "nativeLessEqualGreater", fn: nativeLessEqualGreater, inputs: []int64{98}},
+		"relative base input":      {program: "nativeRelativeInput", fn: nativeRelativeInput, inputs: []int64{8765}},
+		"quine":                    {program: "nativeQuine", fn: nativeQuine},
+		"large multiply":           {program: "nativeLargeMultiply", fn: nativeLargeMultiply},
+		"large output":             {program: "nativeLargeOutput", fn: nativeLargeOutput},
+		"write past 100k":          {program: "nativeWriteFar", fn: nativeWriteFar},
+		"read untouched memory":    {program: "nativeReadUntouched", fn: nativeReadUntouched},
+		"relative write far ahead": {program: "nativeRelativeWriteFar", fn: nativeRelativeWriteFar},
+		"unknown op code":          {program: "nativeUnknownOpCode", fn: nativeUnknownOpCode},
+		"negative position read":   {program: "nativeNegativePositionRead", fn: nativeNegativePositionRead},
+		"negative relative read":   {program: "nativeNegativeRelativeRead", fn: nativeNegativeRelativeRead},
+		"negative jump target":     {program: "nativeNegativeJumpTarget", fn: nativeNegativeJumpTarget},
+		"invalid mode":             {program: "nativeInvalidMode", fn: nativeInvalidMode},
+		"too many modes":           {program: "nativeTooManyModes", fn: nativeTooManyModes},
+		"input exhausted":          {program: "nativeInputTwice", fn: nativeInputTwice, inputs: []int64{5}},
+		"self modifying":           {program: "nativeSelfModifying", fn: nativeSelfModifying},
+		"halt with mode":           {program: "nativeHaltWithMode", fn: nativeHaltWithMode},
+		"gravity assist":           {program: "nativeGravityAssist", fn: nativeGravityAssist},
+		"gravity assist 1202":      {program: "nativeGravityAssist", fn: nativeGravityAssist, writes: map[int]int64{1: 12, 2: 2}},
+		"boost test":               {program: "nativeBoost", fn: nativeBoost, inputs: []int64{1}},
+		"boost sensor":             {program: "nativeBoost", fn: nativeBoost, inputs: []int64{2}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			interpreted := newEngineComputer(t, programs[tc.program], false, tc.inputs...)
+			native := newEngineComputer(t, programs[tc.program], false, tc.inputs...)
+			for addr, val := range tc.writes {
+				require.NoError(t, interpreted.WriteAddr(addr, val))
+				require.NoError(t, native.WriteAddr(addr, val))
+			}
+
+			interpretedErr := interpreted.Run()
+			nativeErr := native.RunNative(tc.fn)
+			if interpretedErr != nil {
+				assert.EqualError(t, nativeErr, interpretedErr.Error())
+			} else {
+				assert.NoError(t, nativeErr)
+			}
+			assert.Equal(t, interpreted.Outputs(), native.Outputs())
+			assert.Equal(t, interpreted.DumpMemory(), native.DumpMemory())
+			assert.Equal(t, interpreted.insPtr, native.insPtr)
+			assert.Equal(t, interpreted.relativeBase, native.relativeBase)
+			assert.Equal(t, interpreted.executed, native.executed)
+		})
+	}
+}
+
+func TestNativeFallback(t *testing.T) {
+	boost := readProgram(t, "../day09/input.txt")
+
+	// the budget runs out part way through a block
+	interpreted := newEngineComputer(t, boost, false, 2)
+	interpreted.MaxInstructions = 1001
+	native := newEngineComputer(t, boost, false, 2)
+	native.MaxInstructions = 1001
+	interpretedErr := interpreted.Run()
+	assert.ErrorIs(t, interpretedErr, ErrInstructionBudget)
+	assert.EqualError(t, native.RunNative(nativeBoost), interpretedErr.Error())
+	assert.Equal(t, interpreted.insPtr, native.insPtr)
+	assert.Equal(t, interpreted.executed, native.executed)
+
+	native = newEngineComputer(t, boost, false, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, native.RunNativeContext(ctx, nativeBoost), context.Canceled)
+	assert.Equal(t, 0, native.insPtr)
+
+	// instrumented computers are interpreted
+	native = newEngineComputer(t, boost, false, 1)
+	profiler := NewProfiler()
+	native.Profiler = profiler
+	require.NoError(t, native.RunNative(nativeBoost))
+	assert.Equal(t, []int64{3601950151}, native.Outputs())
+	assert.NotZero(t, profiler.Total())
+
+	// a program changed after compiling is interpreted
+	native = newEngineComputer(t, "1,0,0,0,99", false)
+	require.NoError(t, native.WriteAddr(4, 104))
+	require.NoError(t, native.WriteAddr(5, 7))
+	require.NoError(t, native.WriteAddr(6, 99))
+	require.NoError(t, native.RunNative(nativeExample1))
+	assert.Equal(t, []int64{7}, native.Outputs())
+	assert.Equal(t, "2,0,0,0,104,7,99", native.DumpMemory())
+}
+
+func TestCompileErrors(t *testing.T) {
+	_, err := Compile("main", NamedProgram{Name: "not valid", Code: "99"})
+	assert.EqualError(t, err, `invalid function name "not valid"`)
+
+	_, err = Compile("main", NamedProgram{Name: "Program", Code: "1,x,99"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,454 @@
+// Package intcode implements the Intcode computer shared by the Advent of Code 2019 puzzles.
+package intcode
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Computer is an Intcode virtual machine
+type Computer struct {
+	Label         string
+	DisableLog    bool
+	DisableOutLog bool
+	// MaxInstructions limits how many instructions a single run or call to Resume may execute, zero means no
+	// limit
+	MaxInstructions int
+	// Arithmetic selects how results outside the int64 range are handled, use NewBigComputer for programs
+	// containing such values
+	Arithmetic Arithmetic
+	// Fast executes instructions with the pre-decoded engine, which is not used while instructions are logged
+	// so set DisableLog as well
+	Fast bool
+	// Tracer receives an event for every executed instruction, the fast engine is not used while tracing
+	Tracer Tracer
+	// Profiler counts instruction and memory use, the fast engine is not used while profiling
+	Profiler *Profiler
+	// History records executed instructions so they can be reversed with StepBack, the fast engine is not
+	// used while recording
+	History *History
+	// LogWriter is where the instruction log is written, stdout if not set
+	LogWriter io.Writer
+
+	ctx          context.Context
+	input        Input
+	queued       []int64
+	output       Output
+	memory       *memory
+	decoded      *decodeCache
+	insPtr       int
+	relativeBase int
+	terminated   bool
+	outputs      []int64
+	bigOutputs   map[int]*big.Int
+	executed     int
+	trace        *TraceEvent
+	profiling    bool
+	undo         *undoEntry
+}
+
+const PostionMode = 0
+const AbsoluteMode = 1
+const RelativeMode = 2
+
+// NewComputer reads in the input data in the form of a single CSV string and uses a scanner to read input
+func NewComputer(inputData string, scanner *bufio.Scanner) (*Computer, error) {
+	memory, err := parseMemoryInput(inputData)
+	if err != nil {
+		return nil, err
+	}
+	c := &Computer{memory: memory, insPtr: 0}
+	if scanner != nil {
+		c.input = ScannerInput(scanner)
+	}
+	return c, nil
+}
+
+// NewChannelComputer reads in the input data in the form of a single CSV string and uses channels to read input and send output
+func NewChannelComputer(inputData string, in <-chan int64, out chan<- int64) (*Computer, error) {
+	memory, err := parseMemoryInput(inputData)
+	if err != nil {
+		return nil, err
+	}
+	c := &Computer{memory: memory, insPtr: 0}
+	if in != nil {
+		c.input = ChannelInput(in)
+	}
+	if out != nil {
+		c.output = ChannelOutput(out)
+	}
+	return c, nil
+}
+
+// NewIOComputer reads in the input data in the form of a single CSV string and uses the provided input and
+// output, either of which may be nil
+func NewIOComputer(inputData string, in Input, out Output) (*Computer, error) {
+	memory, err := parseMemoryInput(inputData)
+	if err != nil {
+		return nil, err
+	}
+	return &Computer{memory: memory, insPtr: 0, input: in, output: out}, nil
+}
+
+func parseMemoryInput(inputData string) (*memory, error) {
+	memoryStr := strings.Split(strings.TrimSpace(inputData), ",")
+	memoryData := make([]int64, len(memoryStr))
+
+	var err error
+	for i, memValStr := range memoryStr {
+		memoryData[i], err = strconv.ParseInt(memValStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newMemory(memoryData), nil
+}
+
+// Clone makes an independent copy of the computer, including its execution state. Input and output are not
+// shared with the clone, so it must be connected to its own before it is run. Values queued with SendInput
+// are copied. Cloning only marks the computer's memory pages as shared, atomically, so one computer can be
+// cloned by several goroutines at once provided none of them runs or writes to it meanwhile.
+func (c *Computer) Clone() *Computer {
+	return &Computer{
+		Label:           c.Label,
+		DisableLog:      c.DisableLog,
+		DisableOutLog:   c.DisableOutLog,
+		MaxInstructions: c.MaxInstructions,
+		Arithmetic:      c.Arithmetic,
+		Fast:            c.Fast,
+		LogWriter:       c.LogWriter,
+		memory:          c.memory.clone(),
+		insPtr:          c.insPtr,
+		relativeBase:    c.relativeBase,
+		terminated:      c.terminated,
+		outputs:         append([]int64(nil), c.outputs...),
+		bigOutputs:      copyBigOutputs(c.bigOutputs),
+		executed:        c.executed,
+		queued:          append([]int64(nil), c.queued...),
+	}
+}
+
+// SetInput replaces where the computer reads input from
+func (c *Computer) SetInput(in Input) {
+	c.input = in
+}
+
+// SetOutput replaces where the computer sends output to, outputs are always recorded in Outputs as well
+func (c *Computer) SetOutput(out Output) {
+	c.output = out
+}
+
+// Run executes the int code currently stored in the provided memory, any error is returned as an
+// *ExecutionError detailing where the computer stopped
+func (c *Computer) Run() error {
+	return c.RunContext(context.Background())
+}
+
+// RunContext executes the int code until it halts or the context is done. Cancellation is checked before
+// every instruction and while blocked on a ContextInput or ContextOutput, the context error is returned
+// wrapped in an *ExecutionError.
+func (c *Computer) RunContext(ctx context.Context) error {
+	defer c.closeOutput()
+	c.ctx = ctx
+	defer func() { c.ctx = nil }()
+	return c.run(ctx, 0)
+}
+
+// closeOutput closes the Output if it is an io.Closer once the program has halted. A run which stopped any
+// other way, such as on its instruction budget, may be resumed so the output is left open.
+func (c *Computer) closeOutput() {
+	if closer, ok := c.output.(io.Closer); ok && c.terminated {
+		closer.Close()
+	}
+}
+
+// run executes instructions until the program halts, counting towards MaxInstructions from the number
+// already executed by the run
+func (c *Computer) run(ctx context.Context, executed int) error {
+	done := ctx.Done()
+	for ; !c.terminated; executed++ {
+		select {
+		case <-done:
+			return c.executionError(c.insPtr, ctx.Err())
+		default:
+		}
+		if c.MaxInstructions > 0 && executed >= c.MaxInstructions {
+			return c.executionError(c.insPtr, ErrInstructionBudget)
+		}
+		if err := c.step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Step executes the single instruction at the instruction pointer
+func (c *Computer) Step() error {
+	opPtr := c.insPtr
+	if c.Tracer != nil {
+		c.trace = &TraceEvent{
+			Step:         c.executed,
+			Addr:         opPtr,
+			OpCode:       c.nextOpCode(),
+			RelativeBase: c.relativeBase,
+		}
+		defer func() { c.trace = nil }()
+	}
+	if c.History != nil {
+		c.beginUndo()
+		defer func() { c.undo = nil }()
+	}
+
+	// Read current operation
+	code := c.nextOpCode()
+	op, err := readOp(c)
+	if err != nil {
+		return c.executionError(opPtr, err)
+	}
+	size := c.insPtr - opPtr
+
+	// Apply operation
+	if c.Profiler != nil {
+		c.Profiler.begin(c)
+		c.profiling = true
+	}
+	err = op.Apply(c)
+	c.profiling = false
+	if err != nil {
+		// failed instructions are not executed so there is nothing to step back through
+		return c.executionError(opPtr, err)
+	}
+	if c.undo != nil {
+		c.History.push(*c.undo)
+	}
+	c.executed++
+	if c.trace != nil {
+		c.Tracer.Trace(*c.trace)
+	}
+	if c.Profiler != nil {
+		c.Profiler.recordInstruction(c, opPtr, size, code)
+	}
+	return nil
+}
+
+// step executes the next instruction with the engine selected for the computer
+func (c *Computer) step() error {
+	if c.Fast && !c.instrumented() && !c.memory.hasBig() {
+		return c.stepFast()
+	}
+	return c.Step()
+}
+
+// instrumented reports if anything is observing individual instructions, which requires Step
+func (c *Computer) instrumented() bool {
+	return !c.DisableLog || c.Tracer != nil || c.Profiler != nil || c.History != nil
+}
+
+// context gives the context of the current run, steps made outside of a run are never cancelled
+func (c *Computer) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// nextOpCode gives the op code of the instruction at the instruction pointer without decoding it
+func (c *Computer) nextOpCode() OpCode {
+	return OpCode(c.ReadAddr(c.insPtr) % 100)
+}
+
+// executionError records the state of the computer when an instruction failed, the instruction pointer is
+// reset to the start of the failed instruction
+func (c *Computer) executionError(opPtr int, err error) error {
+	c.insPtr = opPtr
+	return &ExecutionError{Label: c.Label, InsPtr: opPtr, RelativeBase: c.relativeBase, Err: err}
+}
+
+// Terminated reports whether the computer has halted
+func (c *Computer) Terminated() bool {
+	return c.terminated
+}
+
+// Outputs returns every value output by the computer so far
+func (c *Computer) Outputs() []int64 {
+	return c.outputs
+}
+
+// ReadAddr reads the memory value at an absolute address, addresses outside of memory read as zero
+func (c *Computer) ReadAddr(addr int) int64 {
+	if c.addrOutOfBounds(addr) {
+		return 0
+	}
+	return c.memory.get(addr)
+}
+
+// WriteAddr stores a memory value at an absolute address
+func (c *Computer) WriteAddr(addr int, val int64) error {
+	return c.storeAtAddr(param{val: int64(addr), mode: PostionMode}, val)
+}
+
+// addrOutOfBounds detects if the provided pointer is out of bounds, memory grows on demand so only negative
+// addresses are invalid
+func (c *Computer) addrOutOfBounds(addr int) bool {
+	return addr < 0
+}
+
+// read current memory value at run position and advance
+func (c *Computer) read() (int64, error) {
+	if c.addrOutOfBounds(c.insPtr) {
+		return -1, &OutOfBoundsError{Addr: c.insPtr}
+	}
+	result := c.memory.get(c.insPtr)
+	c.insPtr++
+	return result, nil
+}
+
+// read memory value based on mode
+func (c *Computer) readMode(p param) (int64, error) {
+	var addr int
+	switch p.mode {
+	case PostionMode:
+		addr = int(p.val)
+	case AbsoluteMode:
+		c.traceOperand(p.val)
+		return p.val, nil
+	case RelativeMode:
+		addr = int(p.val) + c.relativeBase
+	default:
+		return 0, &InvalidModeError{Mode: p.mode}
+	}
+	if c.addrOutOfBounds(addr) {
+		return 0, &OutOfBoundsError{Addr: addr}
+	}
+	val := c.memory.get(addr)
+	c.traceOperand(val)
+	c.profileRead(addr)
+	return val, nil
+}
+
+// store memory value at the address referenced by the param
+func (c *Computer) storeAtAddr(p param, val int64) error {
+	addr := int(p.val)
+	if p.mode == RelativeMode {
+		addr += c.relativeBase
+	}
+	if c.addrOutOfBounds(addr) {
+		return &OutOfBoundsError{Addr: addr}
+	}
+	c.recordWrite(addr, val)
+	c.memory.set(addr, val)
+	if c.decoded != nil {
+		c.decoded.invalidate(addr)
+	}
+	if c.trace != nil {
+		c.trace.Write = &TraceWrite{Addr: addr, Value: val}
+	}
+	c.profileWrite(addr)
+	return nil
+}
+
+// readInput reads the next input value, values queued with SendInput are read before the computer's Input
+func (c *Computer) readInput() (int64, error) {
+	if len(c.queued) != 0 {
+		input := c.queued[0]
+		// shift down rather than reslicing so the queue reuses its storage
+		c.queued = append(c.queued[:0], c.queued[1:]...)
+		if !c.DisableOutLog {
+			c.logOutf("%sIN : %d%s\n", Green, input, Reset)
+		}
+		c.recordInput(input)
+		return input, nil
+	}
+	if c.input == nil {
+		return 0, ErrNoInput
+	}
+
+	_, interactive := c.input.(*scannerInput)
+	if interactive {
+		c.logOutf("%sENTER INPUT: %s", Blue, Reset)
+	}
+	var input int64
+	var err error
+	if in, ok := c.input.(ContextInput); ok {
+		input, err = in.ReadInputContext(c.context())
+	} else {
+		input, err = c.input.ReadInput()
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !interactive && !c.DisableOutLog {
+		c.logOutf("%sIN : %d%s\n", Green, input, Reset)
+	}
+	c.recordInput(input)
+	return input, nil
+}
+
+// writeOutput sends a value to the computer's Output and records it in Outputs
+func (c *Computer) writeOutput(out int64) error {
+	var err error
+	switch output := c.output.(type) {
+	case nil:
+	case ContextOutput:
+		err = output.WriteOutputContext(c.context(), out)
+	default:
+		err = output.WriteOutput(out)
+	}
+	if err != nil {
+		return err
+	}
+	c.outputs = append(c.outputs, out)
+	if !c.DisableOutLog {
+		// checked here as well as in logOutf to avoid boxing the args for every output
+		c.logOutf("%sOUT : %d%s\n", Green, out, Reset)
+	}
+	return nil
+}
+
+// DumpMemory dumps memory out into a CSV
+func (c *Computer) DumpMemory() string {
+	dump := make([]string, c.memory.len())
+	for i := range dump {
+		if val := c.memory.getBig(i); val != nil {
+			dump[i] = val.String()
+			continue
+		}
+		dump[i] = strconv.FormatInt(c.memory.get(i), 10)
+	}
+	return strings.Join(dump, ",")
+}
+
+func (c *Computer) logOutf(format string, args ...interface{}) {
+	if c.DisableOutLog {
+		return
+	}
+
+	if c.Label != "" {
+		format = fmt.Sprintf("[%s] %s", c.Label, format)
+	}
+	fmt.Fprintf(c.logWriter(), format, args...)
+}
+
+func (c *Computer) logf(format string, args ...interface{}) {
+	if c.DisableLog {
+		return
+	}
+
+	if c.Label != "" {
+		format = fmt.Sprintf("[%s] %s", c.Label, format)
+	}
+	fmt.Fprintf(c.logWriter(), format, args...)
+}
+
+func (c *Computer) logWriter() io.Writer {
+	if c.LogWriter == nil {
+		return os.Stdout
+	}
+	return c.LogWriter
+}
@@ -0,0 +1,72 @@
+package intcode
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// InOutComputer runs a computer in the background, exposing its input and output channels
+type InOutComputer struct {
+	comp   *Computer
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+	In     chan<- int64
+	Out    <-chan int64
+}
+
+// NewInOutComputer starts the program running, blocking until input is provided. Stop must be called
+// once the computer is no longer needed to release its goroutine.
+func NewInOutComputer(inputText string) (*InOutComputer, error) {
+	in := make(chan int64)
+	out := make(chan int64)
+	c, err := NewChannelComputer(inputText, in, out)
+	if err != nil {
+		return nil, err
+	}
+	c.DisableLog = true
+	c.DisableOutLog = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := &InOutComputer{
+		comp:   c,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		In:     in,
+		Out:    out,
+	}
+
+	go func() {
+		defer close(result.done)
+		result.err = c.RunContext(ctx)
+	}()
+	return result, nil
+}
+
+// Input sends a single value to the computer and returns the single value it outputs in response, zero is
+// returned if the computer has stopped
+func (i *InOutComputer) Input(val int) int {
+	select {
+	case i.In <- int64(val):
+	case <-i.done:
+		return 0
+	}
+	select {
+	case out := <-i.Out:
+		return int(out)
+	case <-i.done:
+		return 0
+	}
+}
+
+// Stop cancels the computer if it is still running and waits for it to exit, returning the error it
+// stopped with (nil if it halted or was stopped)
+func (i *InOutComputer) Stop() error {
+	i.cancel()
+	<-i.done
+	if errors.Is(i.err, context.Canceled) {
+		return nil
+	}
+	return i.err
+}
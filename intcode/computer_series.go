@@ -0,0 +1,262 @@
+package intcode
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SeriesComputer chains computers so the output of one is the input of the next
+type SeriesComputer struct {
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	errs       []error
+	ctx        context.Context
+	cancel     context.CancelFunc
+	comps      []*Computer
+	inputChans []chan int64
+	outputChan chan int64
+
+	// running, blocked and pending count the computers still running, those waiting for input and the
+	// values sent to an input which have not been read. Once waiting is set by WaitForCompletion nothing
+	// more will be sent from outside, so if every running computer is blocked with nothing pending they are
+	// deadlocked.
+	running    int
+	blocked    int
+	pending    int
+	waiting    bool
+	deadlocked bool
+}
+
+// NewSeriesComputer creates a computer per label, each running the same program, connected in a chain
+func NewSeriesComputer(inputText string, labels ...string) (*SeriesComputer, error) {
+	template, err := NewChannelComputer(inputText, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input to int code memory")
+	}
+
+	series := &SeriesComputer{}
+
+	connectChan := make(chan int64, 1)
+	for _, label := range labels {
+		series.inputChans = append(series.inputChans, connectChan)
+		next := make(chan int64, 1)
+
+		comp := template.Clone()
+		comp.input = &seriesInput{series: series, ch: connectChan}
+		comp.output = &seriesOutput{series: series, ch: next}
+		comp.Label = label
+		comp.DisableLog = true
+		series.comps = append(series.comps, comp)
+
+		connectChan = next
+	}
+	series.outputChan = connectChan
+	return series, nil
+}
+
+// NewFeedbackComputer creates a series computer where the last output is fed back into the first input
+func NewFeedbackComputer(inputText string, labels ...string) (*SeriesComputer, error) {
+	series, err := NewSeriesComputer(inputText, labels...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input to int code memory")
+	}
+
+	// connect output back to first input
+	series.comps[0].input = &seriesInput{series: series, ch: series.outputChan}
+	series.inputChans[0] = series.outputChan
+	return series, nil
+}
+
+// RunAsync starts computers running which will block until inputs are met
+func (s *SeriesComputer) RunAsync() {
+	s.RunAsyncContext(context.Background())
+}
+
+// RunAsyncContext starts computers running which stop when the context is done. If any computer fails the
+// rest are cancelled so that none are left blocked waiting on it.
+func (s *SeriesComputer) RunAsyncContext(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.ctx, s.cancel = ctx, cancel
+	s.running = len(s.comps)
+	for _, comp := range s.comps {
+		cToRun := comp
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			err := cToRun.RunContext(ctx)
+			if closer, ok := cToRun.output.(io.Closer); ok && err != nil {
+				// a failed computer is not resumed, so close its output for anything waiting on it
+				closer.Close()
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if err != nil {
+				cToRun.logf("error running computer: %s\n", err.Error())
+				s.errs = append(s.errs, err)
+				cancel()
+			}
+			s.running--
+			s.checkDeadlock()
+		}()
+	}
+}
+
+// WaitForCompletion blocks until every computer in the series has stopped, returning the first error raised.
+// If every computer still running is waiting for input that nothing can send, they are stopped and a
+// *DeadlockError describing each of them is returned.
+func (s *SeriesComputer) WaitForCompletion() error {
+	s.mu.Lock()
+	s.waiting = true
+	s.checkDeadlock()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if s.deadlocked {
+		err := &DeadlockError{}
+		for _, comp := range s.comps {
+			err.Nodes = append(err.Nodes, nodeState(comp.Label, comp))
+		}
+		return err
+	}
+	if len(s.errs) != 0 {
+		return s.errs[0]
+	}
+	return nil
+}
+
+// checkDeadlock stops the computers if they are deadlocked, it must be called with mu held
+func (s *SeriesComputer) checkDeadlock() {
+	if !s.waiting || s.ctx == nil || s.ctx.Err() != nil {
+		return
+	}
+	if s.running > 0 && s.blocked == s.running && s.pending == 0 {
+		s.deadlocked = true
+		s.cancel()
+	}
+}
+
+// isInput reports if a channel is the input of a computer in the series
+func (s *SeriesComputer) isInput(ch chan int64) bool {
+	for _, in := range s.inputChans {
+		if in == ch {
+			return true
+		}
+	}
+	return false
+}
+
+// send sends a value from outside the series to a channel
+func (s *SeriesComputer) send(ch chan int64, val int64) {
+	if s.isInput(ch) {
+		s.mu.Lock()
+		s.pending++
+		s.mu.Unlock()
+	}
+	ch <- val
+}
+
+// LoadPhases sends each computer its phase setting as its first input
+func (s *SeriesComputer) LoadPhases(phases []int) error {
+	if len(phases) != len(s.comps) {
+		return errors.Errorf("incorrect number of phases provided (got %d, want %d)", len(phases), len(s.comps))
+	}
+
+	for i, phase := range phases {
+		s.send(s.inputChans[i], int64(phase))
+	}
+	return nil
+}
+
+// Input sends a value to the first computer in the series
+func (s *SeriesComputer) Input(arg int64) {
+	s.send(s.inputChans[0], arg)
+}
+
+// Output receives a value from the last computer in the series
+func (s *SeriesComputer) Output() int64 {
+	val, ok := <-s.outputChan
+	if ok && s.isInput(s.outputChan) {
+		s.mu.Lock()
+		s.pending--
+		s.mu.Unlock()
+	}
+	return val
+}
+
+// seriesInput reads from a channel between computers, counting how long the computer is blocked for
+type seriesInput struct {
+	series *SeriesComputer
+	ch     chan int64
+}
+
+func (i *seriesInput) ReadInput() (int64, error) {
+	return i.ReadInputContext(context.Background())
+}
+
+func (i *seriesInput) ReadInputContext(ctx context.Context) (int64, error) {
+	s := i.series
+	s.mu.Lock()
+	s.blocked++
+	s.checkDeadlock()
+	s.mu.Unlock()
+
+	select {
+	case val, ok := <-i.ch:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.blocked--
+		if !ok {
+			return 0, ErrInputClosed
+		}
+		s.pending--
+		return val, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.blocked--
+		return 0, ctx.Err()
+	}
+}
+
+// seriesOutput sends to a channel between computers, values sent to another computer are counted as pending
+// until it reads them
+type seriesOutput struct {
+	series *SeriesComputer
+	ch     chan int64
+}
+
+func (o *seriesOutput) WriteOutput(val int64) error {
+	return o.WriteOutputContext(context.Background(), val)
+}
+
+func (o *seriesOutput) WriteOutputContext(ctx context.Context, val int64) error {
+	s := o.series
+	counted := s.isInput(o.ch)
+	if counted {
+		s.mu.Lock()
+		s.pending++
+		s.mu.Unlock()
+	}
+	select {
+	case o.ch <- val:
+		return nil
+	case <-ctx.Done():
+		if counted {
+			s.mu.Lock()
+			s.pending--
+			s.mu.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+func (o *seriesOutput) Close() error {
+	close(o.ch)
+	return nil
+}
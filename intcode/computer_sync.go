@@ -0,0 +1,82 @@
+package intcode
+
+import "context"
+
+// Status is the reason Resume returned control to the caller
+type Status int
+
+const (
+	// Halted means the program has finished
+	Halted Status = iota
+	// NeedsInput means the next instruction reads input and no value has been sent
+	NeedsInput
+	// HasOutput means an output instruction has just executed, its value is available from LastOutput
+	HasOutput
+	// Failed means the program stopped with an error before halting, such as running past its instruction
+	// budget, and is given along with the error
+	Failed
+)
+
+func (s Status) String() string {
+	switch s {
+	case Halted:
+		return "Halted"
+	case NeedsInput:
+		return "NeedsInput"
+	case HasOutput:
+		return "HasOutput"
+	case Failed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// Resume executes instructions until the program halts, produces an output or needs an input which has
+// not been provided with SendInput. Execution is synchronous so no goroutines or channels are required,
+// the caller feeds values in and resumes until it is Halted. MaxInstructions limits each call, the status is
+// Failed whenever an error is returned.
+func (c *Computer) Resume() (Status, error) {
+	return c.ResumeContext(context.Background())
+}
+
+// ResumeContext resumes like Resume, failing with the context error if the context is done first
+func (c *Computer) ResumeContext(ctx context.Context) (Status, error) {
+	done := ctx.Done()
+	for executed := 0; !c.terminated; executed++ {
+		if len(c.queued) == 0 && c.nextOpCode() == OpCodeInput {
+			return NeedsInput, nil
+		}
+		select {
+		case <-done:
+			return Failed, c.executionError(c.insPtr, ctx.Err())
+		default:
+		}
+		if c.MaxInstructions > 0 && executed >= c.MaxInstructions {
+			return Failed, c.executionError(c.insPtr, ErrInstructionBudget)
+		}
+
+		outputs := len(c.outputs)
+		if err := c.step(); err != nil {
+			return Failed, err
+		}
+		if len(c.outputs) > outputs {
+			return HasOutput, nil
+		}
+	}
+	return Halted, nil
+}
+
+// SendInput queues values to be read by input instructions, queued values are read before any Input set
+// on the computer
+func (c *Computer) SendInput(vals ...int64) {
+	c.queued = append(c.queued, vals...)
+}
+
+// LastOutput returns the most recent value output by the computer, zero if nothing has been output
+func (c *Computer) LastOutput() int64 {
+	if len(c.outputs) == 0 {
+		return 0
+	}
+	return c.outputs[len(c.outputs)-1]
+}
@@ -0,0 +1,102 @@
+package intcode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResume(t *testing.T) {
+	// Reads two values and outputs their sum and product
+	testComp, err := NewComputer("3,17,3,18,1,17,18,19,4,19,2,17,18,19,4,19,99", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+
+	type resumeResult struct {
+		status Status
+		output int64
+	}
+	var results []resumeResult
+	inputs := []int64{3, 4}
+	for {
+		status, err := testComp.Resume()
+		require.NoError(t, err)
+		results = append(results, resumeResult{status: status, output: testComp.LastOutput()})
+		if status == Halted {
+			break
+		}
+		if status == NeedsInput {
+			testComp.SendInput(inputs[0])
+			inputs = inputs[1:]
+		}
+	}
+
+	assert.Equal(t, []resumeResult{
+		{status: NeedsInput},
+		{status: NeedsInput},
+		{status: HasOutput, output: 7},
+		{status: HasOutput, output: 12},
+		{status: Halted, output: 12},
+	}, results)
+}
+
+func TestResumeWithQueuedInput(t *testing.T) {
+	testComp, err := NewComputer("3,0,3,1,4,0,4,1,99", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+	testComp.SendInput(5, 6)
+
+	status, err := testComp.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, HasOutput, status)
+	assert.Equal(t, int64(5), testComp.LastOutput())
+
+	// a halted computer stays halted
+	for i := 0; i < 3; i++ {
+		status, err = testComp.Resume()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, Halted, status)
+	assert.Equal(t, []int64{5, 6}, testComp.Outputs())
+}
+
+func TestResumeError(t *testing.T) {
+	testComp, err := NewComputer("1,-1,0,0,99", nil)
+	require.NoError(t, err)
+
+	status, err := testComp.Resume()
+	assert.Equal(t, Failed, status)
+	assert.ErrorAs(t, err, new(*OutOfBoundsError))
+	assert.False(t, testComp.Terminated())
+}
+
+func TestResumeBudget(t *testing.T) {
+	// Loops forever
+	testComp, err := NewComputer("1105,1,0", nil)
+	require.NoError(t, err)
+	testComp.MaxInstructions = 100
+	status, err := testComp.Resume()
+	assert.Equal(t, Failed, status)
+	assert.ErrorIs(t, err, ErrInstructionBudget)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status, err = testComp.ResumeContext(ctx)
+	assert.Equal(t, Failed, status)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Outputs 1 forever, the budget applies to each call
+	testComp, err = NewComputer("104,1,1105,1,0", nil)
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+	testComp.MaxInstructions = 3
+	for i := 0; i < 5; i++ {
+		status, err = testComp.Resume()
+		require.NoError(t, err)
+		assert.Equal(t, HasOutput, status)
+	}
+}
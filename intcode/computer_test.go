@@ -1,11 +1,13 @@
-package main
+package intcode
 
 import (
 	"bufio"
 	"bytes"
 	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,14 +26,13 @@ func TestExamplePrograms(t *testing.T) {
 
 	for name, tc := range tt {
 		t.Run(name, func(t *testing.T) {
-			testComp, err := newComputer(tc.input, nil)
+			testComp, err := NewComputer(tc.input, nil)
 			require.NoError(t, err)
 
-			err = testComp.run()
+			err = testComp.Run()
 			require.NoError(t, err)
 
-			expComp, _ := newComputer(tc.expOutput, nil)
-			assert.Equal(t, expComp.dumpMemory(), testComp.dumpMemory())
+			assert.Equal(t, tc.expOutput, testComp.DumpMemory())
 		})
 	}
 }
@@ -50,14 +51,13 @@ func TestProgramsWithInput(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			buffer := bytes.NewBufferString(tc.inputVal)
 			scanner := bufio.NewScanner(buffer)
-			testComp, err := newComputer(tc.inputCode, scanner)
+			testComp, err := NewComputer(tc.inputCode, scanner)
 			require.NoError(t, err)
 
-			err = testComp.run()
+			err = testComp.Run()
 			require.NoError(t, err)
 
-			expComp, _ := newComputer(tc.expOutput, nil)
-			assert.Equal(t, expComp.dumpMemory(), testComp.dumpMemory())
+			assert.Equal(t, tc.expOutput, testComp.DumpMemory())
 		})
 	}
 }
@@ -72,14 +72,13 @@ func TestImmediatePrograms(t *testing.T) {
 
 	for name, tc := range tt {
 		t.Run(name, func(t *testing.T) {
-			testComp, err := newComputer(tc.input, nil)
+			testComp, err := NewComputer(tc.input, nil)
 			require.NoError(t, err)
 
-			err = testComp.run()
+			err = testComp.Run()
 			require.NoError(t, err)
 
-			expComp, _ := newComputer(tc.expOutput, nil)
-			assert.Equal(t, expComp.dumpMemory(), testComp.dumpMemory())
+			assert.Equal(t, tc.expOutput, testComp.DumpMemory())
 		})
 	}
 }
@@ -141,14 +140,14 @@ func TestProgramsWithInputAndOutput(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			buffer := bytes.NewBufferString(strconv.FormatInt(tc.input, 10))
 			scanner := bufio.NewScanner(buffer)
-			testComp, err := newComputer(tc.inputCode, scanner)
+			testComp, err := NewComputer(tc.inputCode, scanner)
 			require.NoError(t, err)
 
-			err = testComp.run()
+			err = testComp.Run()
 			require.NoError(t, err)
 
-			assert.Len(t, testComp.outputs, 1)
-			assert.Equal(t, tc.output, testComp.outputs[0])
+			assert.Len(t, testComp.Outputs(), 1)
+			assert.Equal(t, tc.output, testComp.Outputs()[0])
 		})
 	}
 }
@@ -206,11 +205,11 @@ func TestProgramsWithInputAndOutputUsingChannels(t *testing.T) {
 			in := make(chan int64)
 			out := make(chan int64)
 
-			testComp, err := newChannelComputer(tc.inputCode, in, out)
+			testComp, err := NewChannelComputer(tc.inputCode, in, out)
 			require.NoError(t, err)
 
 			go func() {
-				err = testComp.run()
+				err = testComp.Run()
 				require.NoError(t, err)
 			}()
 
@@ -235,14 +234,14 @@ func TestSeriesComputer(t *testing.T) {
 
 	for name, tc := range tt {
 		t.Run(name, func(t *testing.T) {
-			testComp, err := newSeriesComputer(tc.inputData, "A", "B", "C", "D", "E")
+			testComp, err := NewSeriesComputer(tc.inputData, "A", "B", "C", "D", "E")
 			require.NoError(t, err)
 
-			testComp.runAsync()
-			testComp.loadPhases(tc.phases)
-			testComp.input(tc.input)
-			output := testComp.output()
-			testComp.waitForCompletion()
+			testComp.RunAsync()
+			testComp.LoadPhases(tc.phases)
+			testComp.Input(tc.input)
+			output := testComp.Output()
+			require.NoError(t, testComp.WaitForCompletion())
 			assert.Equal(t, tc.expOutput, output)
 		})
 	}
@@ -261,15 +260,15 @@ func TestFeedbackComputer(t *testing.T) {
 
 	for name, tc := range tt {
 		t.Run(name, func(t *testing.T) {
-			testComp, err := newFeedbackComputer(tc.inputData, "A", "B", "C", "D", "E")
+			testComp, err := NewFeedbackComputer(tc.inputData, "A", "B", "C", "D", "E")
 			require.NoError(t, err)
 
-			testComp.runAsync()
-			testComp.loadPhases(tc.phases)
-			testComp.input(tc.input)
-			testComp.waitForCompletion()
+			testComp.RunAsync()
+			testComp.LoadPhases(tc.phases)
+			testComp.Input(tc.input)
+			require.NoError(t, testComp.WaitForCompletion())
 
-			output := testComp.output()
+			output := testComp.Output()
 			assert.Equal(t, tc.expOutput, output)
 		})
 	}
@@ -296,13 +295,139 @@ func TestProgramsWithRelativeBase(t *testing.T) {
 
 	for name, tc := range tt {
 		t.Run(name, func(t *testing.T) {
-			testComp, err := newComputer(tc.inputCode, nil)
+			testComp, err := NewComputer(tc.inputCode, nil)
 			require.NoError(t, err)
 
-			err = testComp.run()
+			err = testComp.Run()
 			require.NoError(t, err)
 
-			assert.Equal(t, tc.output, testComp.outputs)
+			assert.Equal(t, tc.output, testComp.Outputs())
 		})
 	}
 }
+
+func TestMemoryGrowsBeyondProgram(t *testing.T) {
+	tt := map[string]struct {
+		inputCode string
+		output    []int64
+	}{
+		"write past 100k":          {inputCode: "1101,5,6,250000,4,250000,99", output: []int64{11}},
+		"read untouched memory":    {inputCode: "4,1000000,99", output: []int64{0}},
+		"relative write far ahead": {inputCode: "109,500000,21101,2,3,7,204,7,99", output: []int64{5}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			testComp, err := NewComputer(tc.inputCode, nil)
+			require.NoError(t, err)
+
+			err = testComp.Run()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.output, testComp.Outputs())
+		})
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	template, err := NewComputer("1,0,0,0,99", nil)
+	require.NoError(t, err)
+	template.WriteAddr(5000, 7)
+
+	clone := template.Clone()
+	clone.WriteAddr(5000, 8)
+	clone.WriteAddr(6000, 9)
+	require.NoError(t, clone.Run())
+
+	assert.Equal(t, "1,0,0,0,99", template.DumpMemory()[:10])
+	assert.Equal(t, int64(7), template.ReadAddr(5000))
+	assert.Equal(t, int64(0), template.ReadAddr(6000))
+
+	assert.Equal(t, "2,0,0,0,99", clone.DumpMemory()[:10])
+	assert.Equal(t, int64(8), clone.ReadAddr(5000))
+	assert.Equal(t, int64(9), clone.ReadAddr(6000))
+}
+
+func TestCloneConcurrently(t *testing.T) {
+	template, err := NewComputer("1,0,0,0,99", nil)
+	require.NoError(t, err)
+	template.DisableLog = true
+	template.WriteAddr(5000, 7)
+
+	var wg sync.WaitGroup
+	clones := make([]*Computer, 8)
+	for i := range clones {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clones[i] = template.Clone()
+			clones[i].WriteAddr(5000, int64(i))
+			assert.NoError(t, clones[i].Run())
+		}(i)
+	}
+	wg.Wait()
+
+	template.WriteAddr(5000, 9)
+	for i, clone := range clones {
+		assert.Equal(t, int64(i), clone.ReadAddr(5000))
+		assert.Equal(t, int64(2), clone.ReadAddr(0))
+	}
+	assert.Equal(t, int64(1), template.ReadAddr(0))
+}
+
+func TestProgramErrors(t *testing.T) {
+	tt := map[string]struct {
+		inputCode string
+		inputVal  string
+		expInsPtr int
+		expErr    error
+	}{
+		"unknown op code":        {inputCode: "1,0,0,0,42", expInsPtr: 4, expErr: &UnknownOpCodeError{OpCode: 42, InsPtr: 4}},
+		"negative position read": {inputCode: "1,-1,0,0,99", expInsPtr: 0, expErr: &OutOfBoundsError{Addr: -1}},
+		"negative relative read": {inputCode: "109,2,204,-3,99", expInsPtr: 2, expErr: &OutOfBoundsError{Addr: -1}},
+		"negative jump target":   {inputCode: "1105,1,-3", expInsPtr: 0, expErr: &OutOfBoundsError{Addr: -3}},
+		"invalid mode":           {inputCode: "301,0,0,0,99", expInsPtr: 0, expErr: &InvalidModeError{Mode: 3, Param: 0}},
+		"too many modes":         {inputCode: "10104,0,99", expInsPtr: 0, expErr: &InvalidModeError{Mode: 1, Param: 2}},
+		"input exhausted":        {inputCode: "3,0,3,0,99", inputVal: "5", expInsPtr: 2, expErr: ErrInputClosed},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			scanner := bufio.NewScanner(bytes.NewBufferString(tc.inputVal))
+			testComp, err := NewComputer(tc.inputCode, scanner)
+			require.NoError(t, err)
+
+			err = testComp.Run()
+			var execErr *ExecutionError
+			require.ErrorAs(t, err, &execErr)
+			assert.Equal(t, tc.expInsPtr, execErr.InsPtr)
+			assert.Equal(t, tc.expErr, errors.Cause(execErr.Err))
+		})
+	}
+}
+
+func TestNoInputMethod(t *testing.T) {
+	testComp, err := NewComputer("3,0,99", nil)
+	require.NoError(t, err)
+
+	err = testComp.Run()
+	assert.ErrorIs(t, err, ErrNoInput)
+}
+
+func TestCloneKeepsExecutionState(t *testing.T) {
+	scanner := bufio.NewScanner(bytes.NewBufferString("7\n"))
+	testComp, err := NewComputer("104,1,109,10,203,0,204,0,99", scanner)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	for i := 0; i < 3; i++ {
+		require.NoError(t, testComp.Step())
+	}
+
+	clone := testComp.Clone()
+	assert.True(t, clone.DisableLog)
+	assert.Equal(t, []int64{1}, clone.Outputs())
+
+	require.NoError(t, clone.Run())
+	assert.Equal(t, []int64{1, 7}, clone.Outputs())
+	assert.Equal(t, []int64{1}, testComp.Outputs())
+}
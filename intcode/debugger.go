@@ -0,0 +1,376 @@
+package intcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// debuggerHistory is the number of instructions the debugger can step back through
+const debuggerHistory = 100000
+
+const debuggerHelp = `commands:
+  s, step [n]          execute the next n instructions (default 1)
+  rs, rstep [n]        step back through the last n instructions (default 1)
+  c, continue          run until a breakpoint, watchpoint, halt or error
+  ni, nextin           run until the next input instruction is about to execute
+  no, nextout          run until the next output instruction has executed
+  b, break <addr>      set a breakpoint at an address
+  d, delete <addr>     remove the breakpoint at an address
+  w, watch <addr>      stop when the value at an address changes
+  uw, unwatch <addr>   remove the watchpoint at an address
+  who <addr>           show the last instruction to write to an address
+  i, info              show the instruction pointer, relative base, breakpoints and watchpoints
+  l, list [addr] [n]   disassemble n instructions from an address (default instruction pointer)
+  x, mem <addr> [n]    show n memory values from an address (default 1)
+  set <addr> <val>     store a value in memory
+  rb [val]             show or set the relative base
+  save <file>          save a snapshot of the machine state to a file
+  load <file>          restore the machine state from a snapshot file
+  h, help              show this help
+  q, quit              exit the debugger
+`
+
+// Debugger is an interactive debugger for stepping through a program on a computer
+type Debugger struct {
+	comp        *Computer
+	in          *bufio.Scanner
+	out         io.Writer
+	breakpoints map[int]bool
+	watchpoints map[int]int64
+}
+
+// NewDebugger creates a debugger reading commands from the scanner and writing to out. If the computer
+// reads input from the same scanner the debugger prompts for it when an input instruction executes. History
+// is recorded so that the debugger can step backwards.
+func NewDebugger(c *Computer, in *bufio.Scanner, out io.Writer) *Debugger {
+	c.DisableLog = true
+	c.DisableOutLog = true
+	if c.History == nil {
+		c.History = NewHistory(debuggerHistory)
+	}
+	return &Debugger{
+		comp:        c,
+		in:          in,
+		out:         out,
+		breakpoints: make(map[int]bool),
+		watchpoints: make(map[int]int64),
+	}
+}
+
+// Run reads and executes commands until quit is entered or the input is exhausted
+func (d *Debugger) Run() {
+	d.printf("%s", debuggerHelp)
+	d.printLocation()
+	for {
+		d.printf("(icdb) ")
+		if !d.in.Scan() {
+			return
+		}
+		quit, err := d.Execute(d.in.Text())
+		if err != nil {
+			d.printf("error: %s\n", err.Error())
+		}
+		if quit {
+			return
+		}
+	}
+}
+
+// Execute runs a single debugger command, reporting if the debugger should exit
+func (d *Debugger) Execute(command string) (bool, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false, nil
+	}
+	switch fields[0] {
+	case "save", "load":
+		return false, d.snapshotCommand(fields[0], fields[1:])
+	}
+
+	args, err := parseArgs(fields[1:])
+	if err != nil {
+		return false, err
+	}
+
+	switch fields[0] {
+	case "s", "step":
+		steps := 1
+		if len(args) > 0 {
+			steps = int(args[0])
+		}
+		for i := 0; i < steps; i++ {
+			if stop, err := d.step(); stop || err != nil {
+				return false, err
+			}
+		}
+		d.printLocation()
+	case "rs", "rstep":
+		steps := 1
+		if len(args) > 0 {
+			steps = int(args[0])
+		}
+		for i := 0; i < steps; i++ {
+			if err := d.comp.StepBack(); err != nil {
+				d.printLocation()
+				return false, err
+			}
+		}
+		d.refreshWatchpoints()
+		d.printLocation()
+	case "c", "continue":
+		return false, d.runUntil(func() bool { return false })
+	case "ni", "nextin":
+		return false, d.runUntil(func() bool { return d.comp.nextOpCode() == OpCodeInput })
+	case "no", "nextout":
+		outputs := len(d.comp.outputs)
+		return false, d.runUntil(func() bool { return len(d.comp.outputs) > outputs })
+	case "b", "break":
+		if len(args) != 1 {
+			return false, errors.New("break requires an address")
+		}
+		d.breakpoints[int(args[0])] = true
+		d.printf("breakpoint set at %d\n", args[0])
+	case "d", "delete":
+		if len(args) != 1 {
+			return false, errors.New("delete requires an address")
+		}
+		delete(d.breakpoints, int(args[0]))
+	case "w", "watch":
+		if len(args) != 1 {
+			return false, errors.New("watch requires an address")
+		}
+		if d.comp.addrOutOfBounds(int(args[0])) {
+			return false, &OutOfBoundsError{Addr: int(args[0])}
+		}
+		d.watchpoints[int(args[0])] = d.comp.ReadAddr(int(args[0]))
+		d.printf("watching %d (currently %d)\n", args[0], d.comp.ReadAddr(int(args[0])))
+	case "uw", "unwatch":
+		if len(args) != 1 {
+			return false, errors.New("unwatch requires an address")
+		}
+		delete(d.watchpoints, int(args[0]))
+	case "who":
+		if len(args) != 1 {
+			return false, errors.New("who requires an address")
+		}
+		w, ok := d.comp.History.LastWriter(int(args[0]))
+		if !ok {
+			d.printf("no write to %d in history\n", args[0])
+			break
+		}
+		d.printf("%d written by instruction at %d (step %d): %d -> %d\n", w.Addr, w.InsPtr, w.Step, w.Old, w.New)
+	case "i", "info":
+		d.printInfo()
+	case "l", "list":
+		addr, count := d.comp.insPtr, 10
+		if len(args) > 0 {
+			addr = int(args[0])
+		}
+		if len(args) > 1 {
+			count = int(args[1])
+		}
+		d.printListing(addr, count)
+	case "x", "mem":
+		if len(args) == 0 {
+			return false, errors.New("mem requires an address")
+		}
+		count := int64(1)
+		if len(args) > 1 {
+			count = args[1]
+		}
+		if count > int64(d.comp.memory.len()) {
+			return false, errors.Errorf("mem count %d is more than the %d memory values", count, d.comp.memory.len())
+		}
+		for addr := int(args[0]); addr < int(args[0]+count); addr++ {
+			d.printf("%6d: %d\n", addr, d.comp.ReadAddr(addr))
+		}
+	case "set":
+		if len(args) != 2 {
+			return false, errors.New("set requires an address and a value")
+		}
+		if err := d.comp.WriteAddr(int(args[0]), args[1]); err != nil {
+			return false, err
+		}
+		if _, ok := d.watchpoints[int(args[0])]; ok {
+			d.watchpoints[int(args[0])] = args[1]
+		}
+	case "rb":
+		if len(args) > 0 {
+			d.comp.relativeBase = int(args[0])
+		}
+		d.printf("relative base: %d\n", d.comp.relativeBase)
+	case "h", "help":
+		d.printf("%s", debuggerHelp)
+	case "q", "quit":
+		return true, nil
+	default:
+		return false, errors.Errorf("unknown command %q, enter help to list commands", fields[0])
+	}
+	return false, nil
+}
+
+// snapshotCommand saves or loads the machine state, the watched values are refreshed after a load
+func (d *Debugger) snapshotCommand(command string, args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf("%s requires a file name", command)
+	}
+
+	if command == "save" {
+		if err := SaveSnapshot(args[0], d.comp.Snapshot()); err != nil {
+			return err
+		}
+		d.printf("saved snapshot to %s\n", args[0])
+		return nil
+	}
+
+	s, err := LoadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+	d.comp.Restore(s)
+	d.refreshWatchpoints()
+	d.printf("loaded snapshot from %s\n", args[0])
+	d.printLocation()
+	return nil
+}
+
+// refreshWatchpoints reads the current value of every watched address, used when the machine state is
+// changed other than by executing instructions
+func (d *Debugger) refreshWatchpoints() {
+	for addr := range d.watchpoints {
+		d.watchpoints[addr] = d.comp.ReadAddr(addr)
+	}
+}
+
+// runUntil keeps stepping until the condition holds or a breakpoint, watchpoint, halt or error stops it
+func (d *Debugger) runUntil(done func() bool) error {
+	for first := true; ; first = false {
+		if !first && d.breakpoints[d.comp.insPtr] {
+			d.printf("breakpoint at %d\n", d.comp.insPtr)
+			break
+		}
+		stop, err := d.step()
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+		if done() {
+			break
+		}
+	}
+	d.printLocation()
+	return nil
+}
+
+// step executes one instruction, reporting if execution should stop due to a watchpoint or halt
+func (d *Debugger) step() (bool, error) {
+	if d.comp.terminated {
+		d.printf("program has halted\n")
+		return true, nil
+	}
+	if in, ok := d.comp.input.(*scannerInput); ok && in.scanner == d.in && d.comp.nextOpCode() == OpCodeInput {
+		d.printf("input> ")
+	}
+
+	outputs := len(d.comp.outputs)
+	if err := d.comp.Step(); err != nil {
+		return true, err
+	}
+	if len(d.comp.outputs) > outputs {
+		d.printf("output: %d\n", d.comp.outputs[len(d.comp.outputs)-1])
+	}
+
+	stop := false
+	for _, addr := range sortedKeys(d.watchpoints) {
+		old, current := d.watchpoints[addr], d.comp.ReadAddr(addr)
+		if old != current {
+			d.printf("watchpoint %d changed: %d -> %d\n", addr, old, current)
+			d.watchpoints[addr] = current
+			stop = true
+		}
+	}
+	if d.comp.terminated {
+		d.printf("program halted\n")
+		stop = true
+	}
+	if stop {
+		d.printLocation()
+	}
+	return stop, nil
+}
+
+func (d *Debugger) printLocation() {
+	if d.comp.terminated {
+		return
+	}
+	d.printListing(d.comp.insPtr, 1)
+}
+
+func (d *Debugger) printListing(addr int, count int) {
+	for i := 0; i < count; i++ {
+		line, ok := decodeListingLine(d.comp, addr)
+		if !ok {
+			line = ListingLine{Addr: addr, Mnemonic: MnemonicData, Raw: []int64{d.comp.ReadAddr(addr)}}
+		}
+		marker := " "
+		switch {
+		case addr == d.comp.insPtr:
+			marker = ">"
+		case d.breakpoints[addr]:
+			marker = "*"
+		}
+		d.printf("%s%s\n", marker, line.String())
+		addr += len(line.Raw)
+	}
+}
+
+func (d *Debugger) printInfo() {
+	d.printf("instruction pointer: %d\n", d.comp.insPtr)
+	d.printf("relative base: %d\n", d.comp.relativeBase)
+	d.printf("halted: %t\n", d.comp.terminated)
+	if len(d.comp.outputs) != 0 {
+		d.printf("outputs: %d (last %d)\n", len(d.comp.outputs), d.comp.outputs[len(d.comp.outputs)-1])
+	} else {
+		d.printf("outputs: 0\n")
+	}
+	var breakpoints []int
+	for addr := range d.breakpoints {
+		breakpoints = append(breakpoints, addr)
+	}
+	sort.Ints(breakpoints)
+	d.printf("breakpoints: %v\n", breakpoints)
+	d.printf("watchpoints: %v\n", sortedKeys(d.watchpoints))
+}
+
+func (d *Debugger) printf(format string, args ...interface{}) {
+	fmt.Fprintf(d.out, format, args...)
+}
+
+func parseArgs(fields []string) ([]int64, error) {
+	args := make([]int64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid number %q", f)
+		}
+		args[i] = v
+	}
+	return args, nil
+}
+
+func sortedKeys(m map[int]int64) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
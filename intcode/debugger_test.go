@@ -0,0 +1,165 @@
+package intcode
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugger(t *testing.T) {
+	// Reads a value then outputs it doubled, twice
+	program := "3,13,1002,13,2,14,4,14,1005,15,12,99,99,0,0,1"
+
+	tt := map[string]struct {
+		commands  []string
+		input     string
+		expOutput []string
+		expInsPtr int
+		expHalted bool
+	}{
+		"step": {
+			commands:  []string{"s", "s 2"},
+			input:     "4",
+			expOutput: []string{">     2: MULT [13], #2, [14]", ">     8: JT   [15], #12"},
+			expInsPtr: 8,
+		},
+		"breakpoint": {
+			commands:  []string{"b 6", "c"},
+			input:     "4",
+			expOutput: []string{"breakpoint set at 6", "breakpoint at 6", ">     6: OUT  [14]"},
+			expInsPtr: 6,
+		},
+		"watchpoint": {
+			commands:  []string{"w 14", "c"},
+			input:     "4",
+			expOutput: []string{"watching 14 (currently 0)", "watchpoint 14 changed: 0 -> 8", ">     6: OUT  [14]"},
+			expInsPtr: 6,
+		},
+		"next output": {
+			commands:  []string{"no"},
+			input:     "5",
+			expOutput: []string{"output: 10", ">     8: JT   [15], #12"},
+			expInsPtr: 8,
+		},
+		"next input": {
+			commands:  []string{"ni"},
+			input:     "5",
+			expOutput: []string{"program halted"},
+			expInsPtr: 13,
+			expHalted: true,
+		},
+		"modify memory": {
+			commands:  []string{"set 15 0", "x 15", "c"},
+			input:     "1",
+			expOutput: []string{"    15: 0", "output: 2", "program halted"},
+			expInsPtr: 12,
+			expHalted: true,
+		},
+		"step back": {
+			commands:  []string{"s 3", "rs 2"},
+			input:     "4",
+			expOutput: []string{"output: 8", ">     2: MULT [13], #2, [14]"},
+			expInsPtr: 2,
+		},
+		"last writer": {
+			commands:  []string{"s 2", "who 14", "who 15"},
+			input:     "4",
+			expOutput: []string{"14 written by instruction at 2 (step 1): 0 -> 8", "no write to 15 in history"},
+			expInsPtr: 6,
+		},
+		"relative base": {
+			commands:  []string{"rb 7", "rb"},
+			expOutput: []string{"relative base: 7", "relative base: 7"},
+		},
+		"list": {
+			commands:  []string{"b 6", "l 0 3"},
+			expOutput: []string{">     0: IN   [13]", "      2: MULT [13], #2, [14]", "*     6: OUT  [14]"},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			testComp, err := NewComputer(program, bufio.NewScanner(strings.NewReader(tc.input)))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			debugger := NewDebugger(testComp, nil, &out)
+			for _, command := range tc.commands {
+				quit, err := debugger.Execute(command)
+				require.NoError(t, err)
+				require.False(t, quit)
+			}
+
+			for _, line := range tc.expOutput {
+				assert.Contains(t, out.String(), line+"\n")
+			}
+			assert.Equal(t, tc.expInsPtr, testComp.insPtr)
+			assert.Equal(t, tc.expHalted, testComp.Terminated())
+		})
+	}
+}
+
+func TestDebuggerSession(t *testing.T) {
+	testComp, err := NewComputer("3,0,4,0,99", nil)
+	require.NoError(t, err)
+
+	// Commands and program input share the same scanner
+	in := bufio.NewScanner(strings.NewReader("s\n42\nc\nq\n"))
+	testComp.SetInput(ScannerInput(in))
+
+	var out bytes.Buffer
+	NewDebugger(testComp, in, &out).Run()
+
+	assert.Contains(t, out.String(), "input> ")
+	assert.Contains(t, out.String(), "output: 42\n")
+	assert.Contains(t, out.String(), "program halted\n")
+	assert.True(t, testComp.Terminated())
+}
+
+func TestDebuggerSaveAndLoad(t *testing.T) {
+	testComp, err := NewComputer("1001,5,1,5,99,0", nil)
+	require.NoError(t, err)
+	debugger := NewDebugger(testComp, nil, &bytes.Buffer{})
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	for _, command := range []string{"w 5", "save " + path, "c", "load " + path} {
+		_, err = debugger.Execute(command)
+		require.NoError(t, err, command)
+	}
+	assert.Equal(t, 0, testComp.insPtr)
+	assert.Equal(t, int64(0), testComp.ReadAddr(5))
+	assert.Equal(t, int64(0), debugger.watchpoints[5])
+
+	_, err = debugger.Execute("load")
+	assert.EqualError(t, err, "load requires a file name")
+}
+
+func TestDebuggerErrors(t *testing.T) {
+	testComp, err := NewComputer("1,-1,0,0,99", nil)
+	require.NoError(t, err)
+	debugger := NewDebugger(testComp, nil, &bytes.Buffer{})
+
+	_, err = debugger.Execute("break")
+	assert.EqualError(t, err, "break requires an address")
+
+	_, err = debugger.Execute("x abc")
+	assert.EqualError(t, err, `invalid number "abc"`)
+
+	_, err = debugger.Execute("x 0 1000000")
+	assert.EqualError(t, err, "mem count 1000000 is more than the 5 memory values")
+
+	_, err = debugger.Execute("jump")
+	assert.EqualError(t, err, `unknown command "jump", enter help to list commands`)
+
+	_, err = debugger.Execute("c")
+	assert.ErrorAs(t, err, new(*OutOfBoundsError))
+
+	quit, err := debugger.Execute("q")
+	assert.NoError(t, err)
+	assert.True(t, quit)
+}
@@ -0,0 +1,267 @@
+package intcode
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxDataPerLine limits how many consecutive data values are grouped onto one listing line
+const maxDataPerLine = 8
+
+// MnemonicData is used for listing lines which are not decodable as instructions
+const MnemonicData = "DATA"
+
+// ListingLine is a single decoded instruction, or a run of data values, from a program
+type ListingLine struct {
+	Addr     int
+	Mnemonic string
+	Operands []string
+	Raw      []int64
+}
+
+func (l ListingLine) String() string {
+	if l.Mnemonic == MnemonicData {
+		values := make([]string, len(l.Raw))
+		for i, v := range l.Raw {
+			values[i] = strconv.FormatInt(v, 10)
+		}
+		return fmt.Sprintf("%6d: %-4s %s", l.Addr, l.Mnemonic, strings.Join(values, ", "))
+	}
+	return strings.TrimRight(fmt.Sprintf("%6d: %-4s %s", l.Addr, l.Mnemonic, strings.Join(l.Operands, ", ")), " ")
+}
+
+// FormatListing joins the listing lines into a printable listing
+func FormatListing(lines []ListingLine) string {
+	var sb strings.Builder
+	for _, l := range lines {
+		sb.WriteString(l.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Disassemble decodes the program linearly from address 0, any cell which is not the start of a valid
+// instruction is listed as data
+func Disassemble(inputData string) ([]ListingLine, error) {
+	c, err := NewComputer(inputData, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	code := make(map[int]ListingLine)
+	for addr := 0; addr < c.memory.len(); {
+		line, ok := decodeListingLine(c, addr)
+		if !ok {
+			addr++
+			continue
+		}
+		code[addr] = line
+		addr += len(line.Raw)
+	}
+	return buildListing(c, code), nil
+}
+
+// DisassembleReachable decodes only the instructions reachable by following control flow from address 0,
+// everything else is listed as data. Jumps with immediate targets are followed, jumps with computed
+// targets are not. Constant addresses pushed onto the relative base stack (the call idiom used to store a
+// return address) are also treated as entry points.
+func DisassembleReachable(inputData string) ([]ListingLine, error) {
+	c, err := NewComputer(inputData, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	code := make(map[int]ListingLine)
+	covered := make(map[int]bool)
+	toVisit := []int{0}
+	for len(toVisit) != 0 {
+		addr := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if covered[addr] {
+			continue
+		}
+
+		line, ok := decodeListingLine(c, addr)
+		if !ok {
+			continue
+		}
+		code[addr] = line
+		for i := range line.Raw {
+			covered[addr+i] = true
+		}
+		toVisit = append(toVisit, successors(c, addr, len(line.Raw))...)
+	}
+	return buildListing(c, code), nil
+}
+
+// decodeAt decodes the instruction at the address without executing it, returning its size in memory. The
+// instruction pointer of the computer is left unchanged.
+func decodeAt(c *Computer, addr int) (instruction, int, error) {
+	insPtr := c.insPtr
+	defer func() { c.insPtr = insPtr }()
+
+	c.insPtr = addr
+	ins, err := readOp(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ins, c.insPtr - addr, nil
+}
+
+// decodeListingLine decodes the instruction at the address, failing if it is invalid, writes to an
+// immediate param, is a halt with mode digits or runs past the end of the program
+func decodeListingLine(c *Computer, addr int) (ListingLine, bool) {
+	ins, size, err := decodeAt(c, addr)
+	if err != nil || addr+size > c.memory.len() {
+		return ListingLine{}, false
+	}
+
+	if _, isHalt := ins.(haltOp); isHalt && c.memory.get(addr) != int64(OpCodeHalt) {
+		// halts ignore mode digits, which would be lost by listing it as HALT
+		return ListingLine{}, false
+	}
+	mnemonic, params := describe(ins)
+	if w := opSpecs[mnemonic].writeParam; w >= 0 && params[w].mode == AbsoluteMode {
+		// written params are never immediate in a valid program
+		return ListingLine{}, false
+	}
+	line := ListingLine{Addr: addr, Mnemonic: mnemonic, Raw: make([]int64, size)}
+	for i := range line.Raw {
+		line.Raw[i] = c.memory.get(addr + i)
+	}
+	for _, p := range params {
+		line.Operands = append(line.Operands, formatParam(p))
+	}
+	return line, true
+}
+
+// successors lists the addresses execution may continue at after the instruction
+func successors(c *Computer, addr int, size int) []int {
+	ins, _, _ := decodeAt(c, addr)
+	next := addr + size
+
+	switch op := ins.(type) {
+	case haltOp:
+		return nil
+	case jumpOp:
+		var result []int
+		cond, target := op.params[0], op.params[1]
+		alwaysJumps := cond.mode == AbsoluteMode && (cond.val != 0) == op.jumpWhen
+		neverJumps := cond.mode == AbsoluteMode && (cond.val != 0) != op.jumpWhen
+		if !alwaysJumps {
+			result = append(result, next)
+		}
+		if !neverJumps && target.mode == AbsoluteMode {
+			result = append(result, int(target.val))
+		}
+		return result
+	case addOp:
+		return append([]int{next}, returnAddress(c, op.binaryOp, next)...)
+	case multiplyOp:
+		return append([]int{next}, returnAddress(c, op.binaryOp, next)...)
+	}
+	return []int{next}
+}
+
+// returnAddress detects the call idiom, where a constant return address is pushed onto the relative base
+// stack immediately before a jump, giving the address execution resumes at after the call
+func returnAddress(c *Computer, op binaryOp, next int) []int {
+	x, y, dest := op.params[0], op.params[1], op.params[2]
+	if x.mode != AbsoluteMode || y.mode != AbsoluteMode || dest.mode != RelativeMode {
+		return nil
+	}
+	ins, size, err := decodeAt(c, next)
+	if _, isJump := ins.(jumpOp); err != nil || !isJump {
+		return nil
+	}
+	ret := int(op.operator(x.val, y.val))
+	if ret != next+size || ret >= c.memory.len() {
+		return nil
+	}
+	return []int{ret}
+}
+
+// buildListing fills the gaps between decoded instructions with data lines
+func buildListing(c *Computer, code map[int]ListingLine) []ListingLine {
+	var addrs []int
+	for addr := range code {
+		addrs = append(addrs, addr)
+	}
+	sort.Ints(addrs)
+
+	var result []ListingLine
+	addData := func(from, to int) {
+		for addr := from; addr < to; addr += maxDataPerLine {
+			end := addr + maxDataPerLine
+			if end > to {
+				end = to
+			}
+			line := ListingLine{Addr: addr, Mnemonic: MnemonicData}
+			for i := addr; i < end; i++ {
+				line.Raw = append(line.Raw, c.memory.get(i))
+			}
+			result = append(result, line)
+		}
+	}
+
+	pos := 0
+	for _, addr := range addrs {
+		if addr < pos {
+			// overlapping instruction, list the cells as data instead
+			continue
+		}
+		addData(pos, addr)
+		result = append(result, code[addr])
+		pos = addr + len(code[addr].Raw)
+	}
+	addData(pos, c.memory.len())
+	return result
+}
+
+// describe gives the mnemonic and params of a decoded instruction
+func describe(ins instruction) (string, []param) {
+	switch op := ins.(type) {
+	case addOp:
+		return "ADD", op.params
+	case multiplyOp:
+		return "MULT", op.params
+	case inputOp:
+		return "IN", op.params
+	case outputOp:
+		return "OUT", op.params
+	case jumpOp:
+		if op.jumpWhen {
+			return "JT", op.params
+		}
+		return "JF", op.params
+	case lessThanOp:
+		return "LT", op.params
+	case equalOp:
+		return "EQ", op.params
+	case shiftRelativeOp:
+		return "ARB", op.params
+	case haltOp:
+		return "HALT", op.params
+	default:
+		return "????", nil
+	}
+}
+
+// formatParam shows a param using [p] for position mode, #imm for immediate mode and rb+n for relative mode
+func formatParam(p param) string {
+	switch p.mode {
+	case PostionMode:
+		return fmt.Sprintf("[%d]", p.val)
+	case AbsoluteMode:
+		return fmt.Sprintf("#%d", p.val)
+	case RelativeMode:
+		if p.val < 0 {
+			return fmt.Sprintf("rb%d", p.val)
+		}
+		return fmt.Sprintf("rb+%d", p.val)
+	default:
+		return strconv.FormatInt(p.val, 10)
+	}
+}
@@ -0,0 +1,95 @@
+package intcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	tt := map[string]struct {
+		inputCode string
+		expOutput string
+	}{
+		"q example": {
+			inputCode: "1,9,10,3,2,3,11,0,99,30,40,50",
+			expOutput: "     0: ADD  [9], [10], [3]\n" +
+				"     4: MULT [3], [11], [0]\n" +
+				"     8: HALT\n" +
+				"     9: DATA 30, 40, 50\n",
+		},
+		"modes": {
+			inputCode: "109,-1,203,1,21101,4,-5,2,1105,1,0,99",
+			expOutput: "     0: ARB  #-1\n" +
+				"     2: IN   rb+1\n" +
+				"     4: ADD  #4, #-5, rb+2\n" +
+				"     8: JT   #1, #0\n" +
+				"    11: HALT\n",
+		},
+		"undecodable data": {
+			inputCode: "104,7,99,42,0,1,1",
+			expOutput: "     0: OUT  #7\n" +
+				"     2: HALT\n" +
+				"     3: DATA 42, 0, 1, 1\n",
+		},
+		"immediate write": {
+			inputCode: "103,5,99",
+			expOutput: "     0: DATA 103, 5\n" +
+				"     2: HALT\n",
+		},
+		"halt with modes": {
+			inputCode: "1099,199,99",
+			expOutput: "     0: DATA 1099, 199\n" +
+				"     2: HALT\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			listing, err := Disassemble(tc.inputCode)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expOutput, FormatListing(listing))
+		})
+	}
+}
+
+func TestDisassembleReachable(t *testing.T) {
+	tt := map[string]struct {
+		inputCode string
+		expOutput string
+	}{
+		"q example": {
+			inputCode: "1,9,10,3,2,3,11,0,99,30,40,50",
+			expOutput: "     0: ADD  [9], [10], [3]\n" +
+				"     4: MULT [3], [11], [0]\n" +
+				"     8: HALT\n" +
+				"     9: DATA 30, 40, 50\n",
+		},
+		"skips over data": {
+			inputCode: "1105,1,5,1,1,104,3,99",
+			expOutput: "     0: JT   #1, #5\n" +
+				"     3: DATA 1, 1\n" +
+				"     5: OUT  #3\n" +
+				"     7: HALT\n",
+		},
+		"follows call return": {
+			inputCode: "21101,7,0,0,1105,1,9,99,0,2106,0,0",
+			expOutput: "     0: ADD  #7, #0, rb+0\n" +
+				"     4: JT   #1, #9\n" +
+				"     7: HALT\n" +
+				"     8: DATA 0\n" +
+				"     9: JF   #0, rb+0\n",
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			listing, err := DisassembleReachable(tc.inputCode)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expOutput, FormatListing(listing))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package intcode
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoInput is returned when a program requests input but the computer has no way of providing it
+var ErrNoInput = errors.New("computer has no input method")
+
+// ErrInputClosed is returned when a program requests input after its input source has been exhausted
+var ErrInputClosed = errors.New("input closed")
+
+// ErrInstructionBudget is returned when a run executes more instructions than the computer's MaxInstructions
+var ErrInstructionBudget = errors.New("instruction budget exceeded")
+
+// ErrDeadlock is matched by every *DeadlockError
+var ErrDeadlock = errors.New("deadlock")
+
+// OutOfBoundsError is returned when an instruction accesses an address outside of memory
+type OutOfBoundsError struct {
+	Addr int
+}
+
+func (e *OutOfBoundsError) Error() string {
+	return fmt.Sprintf("memory out of bounds: address %d", e.Addr)
+}
+
+// InvalidModeError is returned when an instruction parameter has an unrecognized mode
+type InvalidModeError struct {
+	Mode  int
+	Param int
+}
+
+func (e *InvalidModeError) Error() string {
+	return fmt.Sprintf("invalid mode %d for param %d", e.Mode, e.Param)
+}
+
+// UnknownOpCodeError is returned when the value at the instruction pointer is not a recognized op code
+type UnknownOpCodeError struct {
+	OpCode int64
+	InsPtr int
+}
+
+func (e *UnknownOpCodeError) Error() string {
+	return fmt.Sprintf("unrecognized op code %d at %d", e.OpCode, e.InsPtr)
+}
+
+// OverflowError is returned when an instruction produces a value outside the int64 range which the computer
+// cannot store or output
+type OverflowError struct {
+	OpCode   OpCode
+	Operands []*big.Int
+}
+
+func (e *OverflowError) Error() string {
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = operand.String()
+	}
+	return fmt.Sprintf("%s of %s overflows int64", e.OpCode, strings.Join(operands, " and "))
+}
+
+// NodeState is the state of a computer when a deadlock was detected
+type NodeState struct {
+	Label      string
+	InsPtr     int
+	Halted     bool
+	Outputs    int
+	LastOutput int64
+}
+
+func (n NodeState) String() string {
+	state := fmt.Sprintf("waiting at %d", n.InsPtr)
+	if n.Halted {
+		state = fmt.Sprintf("halted at %d", n.InsPtr)
+	}
+	if n.Outputs == 0 {
+		return fmt.Sprintf("[%s] %s, no output", n.Label, state)
+	}
+	return fmt.Sprintf("[%s] %s, last output %d", n.Label, state, n.LastOutput)
+}
+
+// DeadlockError is returned when every running computer connected to others is waiting and none of them
+// can be sent a value
+type DeadlockError struct {
+	Nodes []NodeState
+}
+
+func (e *DeadlockError) Error() string {
+	nodes := make([]string, len(e.Nodes))
+	for i, n := range e.Nodes {
+		nodes[i] = n.String()
+	}
+	return fmt.Sprintf("deadlock, every running computer is waiting: %s", strings.Join(nodes, "; "))
+}
+
+// Is allows errors.Is to match any deadlock with ErrDeadlock
+func (e *DeadlockError) Is(target error) bool {
+	return target == ErrDeadlock
+}
+
+// nodeState describes a computer which is not running, for reporting a deadlock
+func nodeState(label string, c *Computer) NodeState {
+	return NodeState{
+		Label:      label,
+		InsPtr:     c.insPtr,
+		Halted:     c.terminated,
+		Outputs:    len(c.outputs),
+		LastOutput: c.LastOutput(),
+	}
+}
+
+// ExecutionError wraps an error raised while executing an instruction with the state of the computer
+type ExecutionError struct {
+	Label        string
+	InsPtr       int
+	RelativeBase int
+	Err          error
+}
+
+func (e *ExecutionError) Error() string {
+	msg := fmt.Sprintf("instruction at %d (relative base %d): %s", e.InsPtr, e.RelativeBase, e.Err.Error())
+	if e.Label != "" {
+		msg = fmt.Sprintf("[%s] %s", e.Label, msg)
+	}
+	return msg
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ExecutionError) Cause() error {
+	return e.Err
+}
@@ -0,0 +1,229 @@
+package intcode
+
+// maxDecodedAddr limits the addresses cached by the fast engine, instructions beyond it are decoded on
+// every execution rather than growing the cache to cover sparse memory
+const maxDecodedAddr = 1 << 20
+
+// paramSizes gives the number of params for each op code, zero for op codes without params or unknown
+var paramSizes = [100]int{
+	OpCodeAdd:           3,
+	OpCodeMultiply:      3,
+	OpCodeInput:         1,
+	OpCodeOutput:        1,
+	OpCodeJumpIfTrue:    2,
+	OpCodeJumpIfFalse:   2,
+	OpCodeLessThan:      3,
+	OpCodeEqual:         3,
+	OpCodeShiftRelative: 1,
+}
+
+// decodedOp is an instruction decoded by the fast engine. Only valid instructions are decoded, anything
+// else is left to Step.
+type decodedOp struct {
+	valid bool
+	code  OpCode
+	size  int
+	modes [3]int
+	args  [3]int64
+}
+
+// decodeCache holds decoded instructions by address, entries are cleared whenever memory they were decoded
+// from is written to
+type decodeCache struct {
+	ops []decodedOp
+}
+
+// invalidate clears any decoded instruction covering the address
+func (d *decodeCache) invalidate(addr int) {
+	for start := addr - 3; start <= addr; start++ {
+		if start >= 0 && start < len(d.ops) && d.ops[start].valid && start+d.ops[start].size > addr {
+			d.ops[start].valid = false
+		}
+	}
+}
+
+// decodeFast decodes the instruction at the address using arithmetic on the mode digits. Instructions which
+// are invalid or write to an immediate param are not decoded so that Step handles them.
+func (c *Computer) decodeFast(addr int) (decodedOp, bool) {
+	op, ok := c.decode(addr)
+	if !ok || (paramSizes[op.code] == 3 && op.modes[2] == AbsoluteMode) || (op.code == OpCodeInput && op.modes[0] == AbsoluteMode) {
+		return decodedOp{}, false
+	}
+	return op, true
+}
+
+// decode decodes the instruction at the address, reporting false if it is invalid
+func (c *Computer) decode(addr int) (decodedOp, bool) {
+	value := c.memory.get(addr)
+	if value < 0 {
+		return decodedOp{}, false
+	}
+	op := decodedOp{code: OpCode(value % 100)}
+	switch op.code {
+	case OpCodeHalt:
+		if value != int64(OpCodeHalt) {
+			return decodedOp{}, false
+		}
+		op.valid, op.size = true, 1
+		return op, true
+	}
+
+	paramSize := paramSizes[op.code]
+	if paramSize == 0 {
+		return decodedOp{}, false
+	}
+	modes := value / 100
+	for i := 0; i < paramSize; i++ {
+		op.modes[i] = int(modes % 10)
+		if op.modes[i] > RelativeMode {
+			return decodedOp{}, false
+		}
+		op.args[i] = c.memory.get(addr + 1 + i)
+		modes /= 10
+	}
+	if modes != 0 {
+		return decodedOp{}, false
+	}
+	op.valid, op.size = true, paramSize+1
+	return op, true
+}
+
+// decodedAt returns the cached decoded instruction at the address, decoding and caching it if required
+func (c *Computer) decodedAt(addr int) (decodedOp, bool) {
+	if addr >= maxDecodedAddr {
+		return c.decodeFast(addr)
+	}
+	if c.decoded == nil {
+		c.decoded = &decodeCache{}
+	}
+	if addr >= len(c.decoded.ops) {
+		size := c.memory.len()
+		if size < 2*len(c.decoded.ops) {
+			size = 2 * len(c.decoded.ops)
+		}
+		if size <= addr {
+			size = addr + 1
+		}
+		ops := make([]decodedOp, size)
+		copy(ops, c.decoded.ops)
+		c.decoded.ops = ops
+	}
+	if c.decoded.ops[addr].valid {
+		return c.decoded.ops[addr], true
+	}
+	op, ok := c.decodeFast(addr)
+	if ok {
+		c.decoded.ops[addr] = op
+	}
+	return op, ok
+}
+
+// fastAddr gives the address referenced by a position or relative mode param
+func (c *Computer) fastAddr(mode int, arg int64) int {
+	if mode == RelativeMode {
+		return int(arg) + c.relativeBase
+	}
+	return int(arg)
+}
+
+// fastRead reads a param, reporting false if the address is out of bounds
+func (c *Computer) fastRead(mode int, arg int64) (int64, bool) {
+	if mode == AbsoluteMode {
+		return arg, true
+	}
+	addr := c.fastAddr(mode, arg)
+	if addr < 0 {
+		return 0, false
+	}
+	return c.memory.get(addr), true
+}
+
+// stepFast executes the instruction at the instruction pointer using the decode cache. Invalid instructions,
+// out of bounds accesses and overflows when not wrapping are passed to Step so that errors match exactly. Only I/O and halting are
+// logged by the fast engine.
+func (c *Computer) stepFast() error {
+	op, ok := c.decodedAt(c.insPtr)
+	if !ok {
+		return c.Step()
+	}
+
+	switch op.code {
+	case OpCodeAdd, OpCodeMultiply, OpCodeLessThan, OpCodeEqual:
+		x, okX := c.fastRead(op.modes[0], op.args[0])
+		y, okY := c.fastRead(op.modes[1], op.args[1])
+		dest := c.fastAddr(op.modes[2], op.args[2])
+		if !okX || !okY || dest < 0 || (c.Arithmetic != WrapArithmetic && overflows(op.code, x, y)) {
+			return c.Step()
+		}
+		var result int64
+		switch op.code {
+		case OpCodeAdd:
+			result = x + y
+		case OpCodeMultiply:
+			result = x * y
+		case OpCodeLessThan:
+			if x < y {
+				result = 1
+			}
+		case OpCodeEqual:
+			if x == y {
+				result = 1
+			}
+		}
+		c.insPtr += op.size
+		c.memory.set(dest, result)
+		if c.decoded != nil {
+			c.decoded.invalidate(dest)
+		}
+	case OpCodeInput:
+		dest := c.fastAddr(op.modes[0], op.args[0])
+		if dest < 0 {
+			return c.Step()
+		}
+		input, err := c.readInput()
+		if err != nil {
+			return c.executionError(c.insPtr, err)
+		}
+		c.insPtr += op.size
+		c.memory.set(dest, input)
+		if c.decoded != nil {
+			c.decoded.invalidate(dest)
+		}
+	case OpCodeOutput:
+		out, ok := c.fastRead(op.modes[0], op.args[0])
+		if !ok {
+			return c.Step()
+		}
+		if err := c.writeOutput(out); err != nil {
+			return c.executionError(c.insPtr, err)
+		}
+		c.insPtr += op.size
+	case OpCodeJumpIfTrue, OpCodeJumpIfFalse:
+		cond, okCond := c.fastRead(op.modes[0], op.args[0])
+		if !okCond {
+			return c.Step()
+		}
+		if (cond != 0) != (op.code == OpCodeJumpIfTrue) {
+			c.insPtr += op.size
+			break
+		}
+		target, okTarget := c.fastRead(op.modes[1], op.args[1])
+		if !okTarget || target < 0 {
+			return c.Step()
+		}
+		c.insPtr = int(target)
+	case OpCodeShiftRelative:
+		shift, okShift := c.fastRead(op.modes[0], op.args[0])
+		if !okShift {
+			return c.Step()
+		}
+		c.relativeBase += int(shift)
+		c.insPtr += op.size
+	case OpCodeHalt:
+		c.insPtr += op.size
+		c.terminated = true
+		c.logOutf("%sHALT%s\n", Red, Reset)
+	}
+	c.executed++
+	return nil
+}
@@ -0,0 +1,171 @@
+package intcode
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readProgram(tb testing.TB, path string) string {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(tb, err)
+	return string(data)
+}
+
+func newEngineComputer(tb testing.TB, program string, fast bool, inputs ...int64) *Computer {
+	c, err := NewIOComputer(program, SliceInput(inputs...), nil)
+	require.NoError(tb, err)
+	c.DisableLog = true
+	c.DisableOutLog = true
+	c.Fast = fast
+	return c
+}
+
+func TestFastEngineMatchesStep(t *testing.T) {
+	boost := readProgram(t, "../day09/input.txt")
+
+	tt := map[string]struct {
+		inputCode string
+		inputs    []int64
+	}{
+		"quine":            {inputCode: "109,1,204,-1,1001,100,1,100,1008,100,16,101,1006,101,0,99"},
+		"large multiply":   {inputCode: "1102,34915192,34915192,7,4,7,99,0"},
+		"compare to eight": {inputCode: "3,3,1107,-1,8,3,4,3,99", inputs: []int64{5}},
+		"jumps":            {inputCode: "3,12,6,12,15,1,13,14,13,4,13,99,-1,0,1,9", inputs: []int64{0}},
+		// Outputs the operand of its own output instruction, incrementing it each loop
+		"self modifying": {inputCode: "104,0,1001,1,1,1,1007,1,3,20,1005,20,0,99"},
+		"halt with mode": {inputCode: "1101,1,1,5,199"},
+		"boost test":     {inputCode: boost, inputs: []int64{1}},
+		"boost sensor":   {inputCode: boost, inputs: []int64{2}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			slow := newEngineComputer(t, tc.inputCode, false, tc.inputs...)
+			slow.MaxInstructions = 1000000
+			fast := newEngineComputer(t, tc.inputCode, true, tc.inputs...)
+			fast.MaxInstructions = 1000000
+
+			require.NoError(t, slow.Run())
+			require.NoError(t, fast.Run())
+			assert.Equal(t, slow.Outputs(), fast.Outputs())
+			assert.Equal(t, slow.DumpMemory(), fast.DumpMemory())
+			assert.Equal(t, slow.insPtr, fast.insPtr)
+			assert.Equal(t, slow.relativeBase, fast.relativeBase)
+		})
+	}
+}
+
+func TestFastEngineSelfModifying(t *testing.T) {
+	c := newEngineComputer(t, "104,0,1001,1,1,1,1007,1,3,20,1005,20,0,99", true)
+	c.MaxInstructions = 100
+	require.NoError(t, c.Run())
+	assert.Equal(t, []int64{0, 1, 2}, c.Outputs())
+
+	// writes from outside of the program also invalidate decoded instructions
+	c = newEngineComputer(t, "1101,1,1,7,1105,1,0,0", true)
+	c.MaxInstructions = 4
+	assert.ErrorIs(t, c.Run(), ErrInstructionBudget)
+	require.NoError(t, c.WriteAddr(0, 99))
+	require.NoError(t, c.Run())
+	assert.True(t, c.Terminated())
+}
+
+func TestFastEngineKeepsLog(t *testing.T) {
+	logs := make([]string, 2)
+	for i, fast := range []bool{false, true} {
+		var log strings.Builder
+		c := newEngineComputer(t, "1101,2,3,5,1105,1,7,99", fast)
+		c.DisableLog = false
+		c.LogWriter = &log
+		require.NoError(t, c.Run())
+		logs[i] = log.String()
+	}
+	assert.NotEmpty(t, logs[0])
+	assert.Equal(t, logs[0], logs[1])
+}
+
+func TestFastEngineErrors(t *testing.T) {
+	tt := map[string]string{
+		"unknown op code":        "1,0,0,0,42",
+		"negative position read": "1,-1,0,0,99",
+		"negative relative read": "109,2,204,-3,99",
+		"negative write":         "109,-5,21101,1,1,0,99",
+		"negative jump target":   "1105,1,-3",
+		"invalid mode":           "301,0,0,0,99",
+		"too many modes":         "10104,0,99",
+		"input exhausted":        "3,0,3,0,99",
+	}
+
+	for name, inputCode := range tt {
+		t.Run(name, func(t *testing.T) {
+			slow := newEngineComputer(t, inputCode, false, 5)
+			fast := newEngineComputer(t, inputCode, true, 5)
+
+			slowErr := slow.Run()
+			require.Error(t, slowErr)
+			fastErr := fast.Run()
+			assert.EqualError(t, fastErr, slowErr.Error())
+			assert.IsType(t, errors.Cause(slowErr), errors.Cause(fastErr))
+			assert.Equal(t, slow.insPtr, fast.insPtr)
+		})
+	}
+}
+
+func BenchmarkBoost(b *testing.B) {
+	program := readProgram(b, "../day09/input.txt")
+	for _, engine := range []struct {
+		name   string
+		fast   bool
+		native NativeFunc
+	}{{"step", false, nil}, {"fast", true, nil}, {"native", false, nativeBoost}} {
+		b.Run(engine.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c := newEngineComputer(b, program, engine.fast, 2)
+				run := c.Run
+				if engine.native != nil {
+					run = func() error { return c.RunNative(engine.native) }
+				}
+				if err := run(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRepairDroid(b *testing.B) {
+	program := readProgram(b, "../day15/input.txt")
+	for _, engine := range []struct {
+		name string
+		fast bool
+	}{{"step", false}, {"fast", true}} {
+		b.Run(engine.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c := newEngineComputer(b, program, engine.fast)
+				// follow the left wall for a fixed number of moves
+				dir := int64(1)
+				left := map[int64]int64{1: 3, 3: 2, 2: 4, 4: 1}
+				right := map[int64]int64{1: 4, 4: 2, 2: 3, 3: 1}
+				for move := 0; move < 2000; move++ {
+					c.SendInput(left[dir])
+					status, err := c.Resume()
+					if err != nil || status != HasOutput {
+						b.Fatalf("unexpected status %s: %v", status, err)
+					}
+					if c.LastOutput() == 0 {
+						dir = right[dir]
+						continue
+					}
+					dir = left[dir]
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package intcode
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrEngineMismatch is reported as a crash when the fast engine does not behave as Step does
+var ErrEngineMismatch = errors.New("fast engine differs from step")
+
+// defaultFuzzInputs and defaultFuzzBudget limit runs when a Fuzzer does not set its own limits
+const (
+	defaultFuzzInputs = 16
+	defaultFuzzBudget = 100000
+)
+
+// interestingValues are tried as inputs alongside the values given to a Fuzzer
+var interestingValues = []int64{0, 1, -1, 2, 10, 127, 128, 255, 1 << 31, -(1 << 31), 1<<63 - 1, -1 << 63}
+
+// Crash is a run which stopped with an error other than running out of input
+type Crash struct {
+	Inputs []int64
+	// Addr is the instruction pointer when the program stopped
+	Addr int
+	Err  error
+}
+
+func (c Crash) String() string {
+	return fmt.Sprintf("%s, inputs %v", c.Err, c.Inputs)
+}
+
+// Fuzzer searches for input sequences which make a program execute instructions it has not executed before
+// or crash. Each run clones the program and feeds it a sequence mutated from one in the corpus, ending
+// normally if it halts or asks for more input than the sequence holds. Sequences reaching new addresses are
+// added to the corpus. Unknown op codes, out of bounds accesses and running past the instruction budget are
+// reported as crashes, one per kind of error and address.
+type Fuzzer struct {
+	// Values are favoured as inputs, such as the movement commands of a droid
+	Values []int64
+	// MaxInputs limits the length of input sequences, 16 if not set
+	MaxInputs int
+	// MaxInstructions is the budget for each run, programs which do not halt or wait for input within it are
+	// reported as not terminating. Defaults to 100000.
+	MaxInstructions int
+	// CheckEngines repeats every run with the fast engine, reporting any difference as a crash
+	CheckEngines bool
+	// Rand is the source of mutations, seeded with 1 if not set
+	Rand *rand.Rand
+
+	template *Computer
+	coverage map[int]bool
+	corpus   [][]int64
+	crashes  []Crash
+	crashed  map[string]bool
+	runs     int
+}
+
+// NewFuzzer creates a fuzzer for a program with an empty corpus
+func NewFuzzer(inputText string) (*Fuzzer, error) {
+	template, err := NewComputer(inputText, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input to int code memory")
+	}
+	template.DisableLog = true
+	template.DisableOutLog = true
+	return &Fuzzer{template: template, coverage: make(map[int]bool), crashed: make(map[string]bool)}, nil
+}
+
+// Seed runs each of the input sequences, adding those which reach new addresses to the corpus
+func (f *Fuzzer) Seed(sequences ...[]int64) {
+	for _, inputs := range sequences {
+		f.try(inputs)
+	}
+}
+
+// Run makes the number of runs, mutating sequences from the corpus. The empty sequence is run first while
+// the corpus is empty.
+func (f *Fuzzer) Run(runs int) {
+	f.RunContext(context.Background(), runs)
+}
+
+// RunContext makes the number of runs, stopping early with the context error if the context is done
+func (f *Fuzzer) RunContext(ctx context.Context, runs int) error {
+	if f.Rand == nil {
+		f.Rand = rand.New(rand.NewSource(1))
+	}
+	if len(f.corpus) == 0 {
+		f.try(nil)
+	}
+	for i := 0; i < runs; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		parent := []int64(nil)
+		if len(f.corpus) != 0 {
+			parent = f.corpus[f.Rand.Intn(len(f.corpus))]
+		}
+		f.try(f.mutate(parent))
+	}
+	return nil
+}
+
+// Runs is the number of runs made
+func (f *Fuzzer) Runs() int {
+	return f.runs
+}
+
+// Corpus lists the input sequences kept for reaching new addresses, in the order they were found
+func (f *Fuzzer) Corpus() [][]int64 {
+	return f.corpus
+}
+
+// Crashes lists the crashes found, in the order they were found
+func (f *Fuzzer) Crashes() []Crash {
+	return f.crashes
+}
+
+// Coverage lists every address executed by any run
+func (f *Fuzzer) Coverage() []int {
+	var result []int
+	for addr := range f.coverage {
+		result = append(result, addr)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// try runs the program with the inputs, keeping them if they reach new addresses or crash
+func (f *Fuzzer) try(inputs []int64) {
+	f.runs++
+	covered := make(map[int]bool)
+	c := f.template.Clone()
+	c.Tracer = TracerFunc(func(e TraceEvent) { covered[e.Addr] = true })
+	err := f.run(c, inputs)
+
+	if f.CheckEngines {
+		fast := f.template.Clone()
+		fast.Fast = true
+		fastErr := f.run(fast, inputs)
+		if !reflect.DeepEqual(c.Outputs(), fast.Outputs()) || fmt.Sprint(err) != fmt.Sprint(fastErr) || c.insPtr != fast.insPtr {
+			f.crash(inputs, stoppedAt(fast, fastErr), errors.Wrapf(ErrEngineMismatch, "step %v, fast %v", err, fastErr))
+		}
+	}
+
+	isNew := false
+	for addr := range covered {
+		if !f.coverage[addr] {
+			f.coverage[addr] = true
+			isNew = true
+		}
+	}
+	if err != nil && !errors.Is(err, ErrInputClosed) {
+		f.crash(inputs, stoppedAt(c, err), err)
+		return
+	}
+	if isNew {
+		f.corpus = append(f.corpus, inputs)
+	}
+}
+
+// run runs a clone of the template with the inputs until it halts, fails or runs out of input
+func (f *Fuzzer) run(c *Computer, inputs []int64) error {
+	c.MaxInstructions = f.MaxInstructions
+	if c.MaxInstructions <= 0 {
+		c.MaxInstructions = defaultFuzzBudget
+	}
+	c.SetInput(SliceInput(inputs...))
+	return c.Run()
+}
+
+// stoppedAt gives the address of the instruction which failed
+func stoppedAt(c *Computer, err error) int {
+	var execErr *ExecutionError
+	if errors.As(err, &execErr) {
+		return execErr.InsPtr
+	}
+	return c.insPtr
+}
+
+// crash records a crash unless the same error has already been seen at the address
+func (f *Fuzzer) crash(inputs []int64, addr int, err error) {
+	key := fmt.Sprintf("%d %T", addr, errors.Cause(err))
+	if errors.Is(err, ErrInstructionBudget) || errors.Is(err, ErrEngineMismatch) {
+		// where a program stops depends on its budget rather than what went wrong
+		key = errors.Cause(err).Error()
+	}
+	if f.crashed[key] {
+		return
+	}
+	f.crashed[key] = true
+	f.crashes = append(f.crashes, Crash{Inputs: append([]int64(nil), inputs...), Addr: addr, Err: err})
+}
+
+// mutate gives a new sequence derived from the parent, which is left unchanged
+func (f *Fuzzer) mutate(parent []int64) []int64 {
+	maxInputs := f.MaxInputs
+	if maxInputs <= 0 {
+		maxInputs = defaultFuzzInputs
+	}
+	inputs := append([]int64(nil), parent...)
+	for n := 1 + f.Rand.Intn(3); n > 0; n-- {
+		switch op := f.Rand.Intn(5); {
+		case op == 0 || len(inputs) == 0 || (op == 4 && len(f.corpus) == 0):
+			inputs = append(inputs, f.value())
+		case op == 1:
+			inputs[f.Rand.Intn(len(inputs))] = f.value()
+		case op == 2:
+			i := f.Rand.Intn(len(inputs) + 1)
+			inputs = append(inputs[:i], append([]int64{f.value()}, inputs[i:]...)...)
+		case op == 3:
+			i := f.Rand.Intn(len(inputs))
+			inputs = append(inputs[:i], inputs[i+1:]...)
+		default:
+			// splice the start of this sequence with the end of another
+			other := f.corpus[f.Rand.Intn(len(f.corpus))]
+			i, j := f.Rand.Intn(len(inputs)+1), f.Rand.Intn(len(other)+1)
+			inputs = append(inputs[:i], other[j:]...)
+		}
+	}
+	if len(inputs) > maxInputs {
+		inputs = inputs[:maxInputs]
+	}
+	return inputs
+}
+
+// value picks an input value, favouring the fuzzer's Values
+func (f *Fuzzer) value() int64 {
+	switch n := f.Rand.Intn(4); {
+	case len(f.Values) != 0 && n < 3:
+		return f.Values[f.Rand.Intn(len(f.Values))]
+	case n < 2:
+		return interestingValues[f.Rand.Intn(len(interestingValues))]
+	}
+	return f.Rand.Int63n(2001) - 1000
+}
+
+// WriteReport writes a summary of the runs, the corpus and the crashes found
+func (f *Fuzzer) WriteReport(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "runs: %d\n", f.runs)
+	fmt.Fprintf(&sb, "addresses executed: %d\n", len(f.coverage))
+
+	sb.WriteString("\ncorpus:\n")
+	for _, inputs := range f.corpus {
+		fmt.Fprintf(&sb, "  %v\n", inputs)
+	}
+
+	sb.WriteString("\ncrashes:\n")
+	for _, c := range f.crashes {
+		fmt.Fprintf(&sb, "  %s\n", c)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
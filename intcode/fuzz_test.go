@@ -0,0 +1,72 @@
+package intcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzerFindsCrashes(t *testing.T) {
+	tt := map[string]struct {
+		inputCode string
+		values    []int64
+		addr      int
+		err       error
+	}{
+		// runs into an unknown op code when the input is 7
+		"unknown op code": {inputCode: "3,20,1008,20,7,21,1005,21,10,99,42", values: []int64{7}, addr: 10, err: &UnknownOpCodeError{}},
+		// loops forever when the input is not zero
+		"non termination": {inputCode: "3,20,1005,20,2,99", addr: 2, err: ErrInstructionBudget},
+		// outputs the value at the address given as input, which may be negative
+		"out of bounds": {inputCode: "3,3,4,0,99", addr: 2, err: &OutOfBoundsError{}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			f, err := NewFuzzer(tc.inputCode)
+			require.NoError(t, err)
+			f.Values = tc.values
+			f.MaxInstructions = 1000
+			f.CheckEngines = true
+			f.Run(200)
+
+			assert.Equal(t, 201, f.Runs())
+			require.Len(t, f.Crashes(), 1)
+			crash := f.Crashes()[0]
+			assert.Equal(t, tc.addr, crash.Addr)
+			assert.IsType(t, tc.err, errors.Cause(crash.Err))
+
+			// the crash is reproducible from its inputs
+			c := newEngineComputer(t, tc.inputCode, false, crash.Inputs...)
+			c.MaxInstructions = 1000
+			assert.EqualError(t, c.Run(), crash.Err.Error())
+		})
+	}
+}
+
+func TestFuzzerCoverage(t *testing.T) {
+	f, err := NewFuzzer(readProgram(t, "../day15/input.txt"))
+	require.NoError(t, err)
+	f.Values = []int64{1, 2, 3, 4}
+	f.MaxInputs = 8
+	f.CheckEngines = true
+	f.Seed([]int64{1})
+	initial := len(f.Coverage())
+	f.Run(200)
+
+	assert.Empty(t, f.Crashes())
+	assert.Greater(t, len(f.Coverage()), initial)
+	assert.Greater(t, len(f.Corpus()), 1)
+	for _, inputs := range f.Corpus() {
+		assert.LessOrEqual(t, len(inputs), 8)
+	}
+
+	var report strings.Builder
+	require.NoError(t, f.WriteReport(&report))
+	assert.True(t, strings.HasPrefix(report.String(), "runs: 201\n"))
+	assert.Contains(t, report.String(), "\ncorpus:\n  [1]\n")
+	assert.True(t, strings.HasSuffix(report.String(), "\ncrashes:\n"))
+}
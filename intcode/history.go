@@ -0,0 +1,181 @@
+package intcode
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoHistory is returned when stepping back with no recorded instructions left to undo
+var ErrNoHistory = errors.New("no history to step back through")
+
+// WriteRecord describes a memory write made by an instruction, values outside the int64 range are recorded
+// as the nearest int64
+type WriteRecord struct {
+	// Step is the number of instructions executed before the writing instruction
+	Step   int
+	InsPtr int
+	Addr   int
+	Old    int64
+	New    int64
+}
+
+// undoEntry holds everything needed to reverse a single instruction
+type undoEntry struct {
+	step         int
+	insPtr       int
+	relativeBase int
+	terminated   bool
+	outputs      int
+	memorySize   int
+	wrote        bool
+	write        WriteRecord
+	oldBig       *big.Int
+	readInput    bool
+	input        int64
+}
+
+// History is a bounded undo log of executed instructions which allows a computer to step backwards. Set it
+// as the History of a computer before running, the fast engine is not used while recording history.
+//
+// Stepping back restores memory, the instruction pointer and relative base. Outputs are removed from
+// Outputs but cannot be recalled from an Output they were sent to. Input values are queued again, as if
+// sent with SendInput, so that stepping forward again replays the same values.
+type History struct {
+	entries []undoEntry
+	limit   int
+	start   int
+	count   int
+}
+
+// NewHistory creates a history keeping at most limit instructions, older instructions are forgotten. Entries
+// are allocated as instructions are recorded rather than up front.
+func NewHistory(limit int) *History {
+	if limit < 1 {
+		limit = 1
+	}
+	return &History{limit: limit}
+}
+
+// Len is the number of instructions which can be stepped back through
+func (h *History) Len() int {
+	return h.count
+}
+
+// push adds an entry, overwriting the oldest if the history is full
+func (h *History) push(e undoEntry) {
+	if h.count == len(h.entries) && len(h.entries) < h.limit {
+		// nothing has been overwritten before the history first fills, so the entries start at zero
+		h.entries = append(h.entries, e)
+		h.count++
+		return
+	}
+	idx := (h.start + h.count) % len(h.entries)
+	if h.count == len(h.entries) {
+		h.start = (h.start + 1) % len(h.entries)
+	} else {
+		h.count++
+	}
+	h.entries[idx] = e
+}
+
+// pop removes the newest entry
+func (h *History) pop() (undoEntry, bool) {
+	if h.count == 0 {
+		return undoEntry{}, false
+	}
+	h.count--
+	return h.entries[(h.start+h.count)%len(h.entries)], true
+}
+
+// newest returns the entry i instructions before the most recent one
+func (h *History) newest(i int) *undoEntry {
+	return &h.entries[(h.start+h.count-1-i)%len(h.entries)]
+}
+
+// LastWriter finds the most recent instruction still in the history which wrote to the address
+func (h *History) LastWriter(addr int) (WriteRecord, bool) {
+	for i := 0; i < h.count; i++ {
+		e := h.newest(i)
+		if e.wrote && e.write.Addr == addr {
+			return e.write, true
+		}
+	}
+	return WriteRecord{}, false
+}
+
+// Clear forgets every recorded instruction
+func (h *History) Clear() {
+	h.start, h.count = 0, 0
+}
+
+// beginUndo records the state of the computer before an instruction executes, the entry is only pushed to
+// the history once the instruction has succeeded so that a failure does not displace the oldest entry
+func (c *Computer) beginUndo() {
+	c.undo = &undoEntry{
+		step:         c.executed,
+		insPtr:       c.insPtr,
+		relativeBase: c.relativeBase,
+		terminated:   c.terminated,
+		outputs:      len(c.outputs),
+		memorySize:   c.memory.len(),
+	}
+}
+
+// recordWrite adds a memory write to the instruction being recorded, before the write is made
+func (c *Computer) recordWrite(addr int, val int64) {
+	if c.undo == nil {
+		return
+	}
+	c.undo.wrote = true
+	c.undo.write = WriteRecord{Step: c.undo.step, InsPtr: c.undo.insPtr, Addr: addr, Old: c.memory.get(addr), New: val}
+	c.undo.oldBig = c.memory.getBig(addr)
+}
+
+// recordInput adds the input value read to the instruction being recorded
+func (c *Computer) recordInput(val int64) {
+	if c.undo == nil {
+		return
+	}
+	c.undo.readInput = true
+	c.undo.input = val
+}
+
+// StepBack reverses the most recently executed instruction recorded in the computer's History
+func (c *Computer) StepBack() error {
+	if c.History == nil {
+		return ErrNoHistory
+	}
+	e, ok := c.History.pop()
+	if !ok {
+		return ErrNoHistory
+	}
+
+	if e.wrote {
+		if e.oldBig != nil {
+			c.memory.setBig(e.write.Addr, e.oldBig)
+		} else {
+			c.memory.set(e.write.Addr, e.write.Old)
+		}
+		if c.decoded != nil {
+			c.decoded.invalidate(e.write.Addr)
+		}
+	}
+	if c.memory.size > e.memorySize {
+		c.memory.size = e.memorySize
+	}
+	if e.readInput {
+		c.queued = append([]int64{e.input}, c.queued...)
+	}
+	c.executed = e.step
+	c.insPtr = e.insPtr
+	c.relativeBase = e.relativeBase
+	c.terminated = e.terminated
+	c.outputs = c.outputs[:e.outputs]
+	for i := range c.bigOutputs {
+		if i >= e.outputs {
+			delete(c.bigOutputs, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package intcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepBack(t *testing.T) {
+	// Reads a value, outputs it doubled then moves the relative base by it and halts
+	testComp, err := NewComputer("3,11,1002,11,2,12,4,12,9,11,99,0,0", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+	testComp.History = NewHistory(10)
+	testComp.SendInput(5)
+	before := testComp.Snapshot()
+
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, []int64{10}, testComp.Outputs())
+	assert.Equal(t, 5, testComp.History.Len())
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, testComp.StepBack())
+	}
+	assert.Equal(t, before, testComp.Snapshot())
+	assert.Equal(t, []int64{5}, testComp.queued)
+	assert.Equal(t, 0, testComp.executed)
+	assert.Equal(t, ErrNoHistory, testComp.StepBack())
+
+	// stepping forward again replays the input
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, []int64{10}, testComp.Outputs())
+	assert.Equal(t, 5, testComp.relativeBase)
+}
+
+func TestLastWriter(t *testing.T) {
+	// Writes 7 to address 13 twice, then doubles it
+	testComp, err := NewComputer("1101,3,4,13,1101,4,3,13,1,13,13,13,99,0", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.History = NewHistory(10)
+	require.NoError(t, testComp.Run())
+
+	tt := map[string]struct {
+		addr     int
+		expFound bool
+		expWrite WriteRecord
+	}{
+		"latest write":  {addr: 13, expFound: true, expWrite: WriteRecord{Step: 2, InsPtr: 8, Addr: 13, Old: 7, New: 14}},
+		"never written": {addr: 3},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			write, found := testComp.History.LastWriter(tc.addr)
+			assert.Equal(t, tc.expFound, found)
+			assert.Equal(t, tc.expWrite, write)
+		})
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	testComp, err := NewComputer("1101,1,1,20,1101,1,2,20,1101,1,3,20,99", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.History = NewHistory(2)
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, 2, testComp.History.Len())
+
+	require.NoError(t, testComp.StepBack())
+	require.NoError(t, testComp.StepBack())
+	assert.Equal(t, 8, testComp.insPtr)
+	assert.Equal(t, int64(3), testComp.ReadAddr(20))
+	assert.Equal(t, ErrNoHistory, testComp.StepBack())
+}
+
+func TestHistoryGrowsToLimit(t *testing.T) {
+	h := NewHistory(debuggerHistory)
+	assert.Empty(t, h.entries)
+	for i := 0; i < 3; i++ {
+		h.push(undoEntry{step: i})
+	}
+	assert.Equal(t, 3, h.Len())
+	assert.Less(t, cap(h.entries), 100)
+	e, ok := h.pop()
+	assert.True(t, ok)
+	assert.Equal(t, 2, e.step)
+	h.push(undoEntry{step: 5})
+	assert.Equal(t, 5, h.newest(0).step)
+	assert.Equal(t, 0, h.newest(2).step)
+}
+
+func TestHistoryKeptOnFailure(t *testing.T) {
+	// Fills the history with two additions then waits for input
+	testComp, err := NewComputer("1101,1,1,20,1101,1,2,20,3,21,99", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.History = NewHistory(2)
+	require.Error(t, testComp.Run())
+	assert.Equal(t, 2, testComp.History.Len())
+
+	require.NoError(t, testComp.StepBack())
+	require.NoError(t, testComp.StepBack())
+	assert.Equal(t, 0, testComp.insPtr)
+	assert.Equal(t, int64(0), testComp.ReadAddr(20))
+	assert.Equal(t, ErrNoHistory, testComp.StepBack())
+}
+
+func TestStepBackWithoutHistory(t *testing.T) {
+	testComp, err := NewComputer("99", nil)
+	require.NoError(t, err)
+	assert.Equal(t, ErrNoHistory, testComp.StepBack())
+}
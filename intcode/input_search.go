@@ -0,0 +1,321 @@
+package intcode
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoSolution is returned when no values of the params give the wanted result
+var ErrNoSolution = errors.New("no solution found")
+
+// Param is a memory address which is set to each value from Min to Max inclusive while searching
+type Param struct {
+	Addr int
+	Min  int64
+	Max  int64
+}
+
+// LinearModel is a result which is a linear function of the params, Constant plus the sum of each param
+// value multiplied by its coefficient
+type LinearModel struct {
+	Constant     int64
+	Coefficients []int64
+}
+
+// Eval gives the result the model predicts for values of the params
+func (m *LinearModel) Eval(values []int64) int64 {
+	result := m.Constant
+	for i, c := range m.Coefficients {
+		result += c * values[i]
+	}
+	return result
+}
+
+// InputSearch finds values of params which make a program leave the wanted value at the target address
+// after it halts, as in part 2 of day 2. The program is parsed once and cloned for each run.
+type InputSearch struct {
+	Params []Param
+	Target int
+	Want   int64
+	// Workers is the number of runs made at once, the number of CPUs if not set
+	Workers int
+	// MaxInstructions limits each run, zero means no limit
+	MaxInstructions int
+
+	template *Computer
+}
+
+// NewInputSearch creates a search for the params giving the wanted value at the target address
+func NewInputSearch(inputText string, target int, want int64, params ...Param) (*InputSearch, error) {
+	template, err := NewComputer(inputText, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input to int code memory")
+	}
+	template.DisableLog = true
+	template.DisableOutLog = true
+	template.Fast = true
+	return &InputSearch{Params: params, Target: target, Want: want, template: template}, nil
+}
+
+// Result runs the program with values for the params, returning the value left at the target address
+func (s *InputSearch) Result(values []int64) (int64, error) {
+	return s.result(context.Background(), values)
+}
+
+// result runs a clone of the template, which workers share as cloning is safe to do at once
+func (s *InputSearch) result(ctx context.Context, values []int64) (int64, error) {
+	if len(values) != len(s.Params) {
+		return 0, errors.Errorf("incorrect number of values provided (got %d, want %d)", len(values), len(s.Params))
+	}
+	c := s.template.Clone()
+	c.MaxInstructions = s.MaxInstructions
+	for i, p := range s.Params {
+		if err := c.WriteAddr(p.Addr, values[i]); err != nil {
+			return 0, err
+		}
+	}
+	if err := c.RunContext(ctx); err != nil {
+		return 0, errors.Wrapf(err, "values %v", values)
+	}
+	return c.ReadAddr(s.Target), nil
+}
+
+// Solve finds values for the params, solving directly if the result appears to depend linearly on them and
+// searching every value otherwise. Linear only samples a few values so the search is also made if the model
+// gives no solution or the samples could not be run.
+func (s *InputSearch) Solve() ([]int64, error) {
+	model, err := s.Linear()
+	if err == nil && model != nil {
+		if solution, err := s.solveLinear(model); err == nil {
+			return solution, nil
+		}
+	}
+	return s.Find()
+}
+
+// Linear detects if the result is a linear function of the params by measuring the effect of each param
+// from the minimum values, then checking the model predicts the result at the maximum values, midpoint and
+// for every pair of params moved together. A nil model is returned if the result is not linear.
+func (s *InputSearch) Linear() (*LinearModel, error) {
+	base := make([]int64, len(s.Params))
+	for i, p := range s.Params {
+		base[i] = p.Min
+	}
+	baseResult, err := s.Result(base)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &LinearModel{Constant: baseResult, Coefficients: make([]int64, len(s.Params))}
+	for i, p := range s.Params {
+		if p.Max == p.Min {
+			continue
+		}
+		values := append([]int64(nil), base...)
+		values[i]++
+		result, err := s.Result(values)
+		if err != nil {
+			return nil, err
+		}
+		model.Coefficients[i] = result - baseResult
+		model.Constant -= model.Coefficients[i] * p.Min
+	}
+
+	var checks [][]int64
+	maxValues := make([]int64, len(s.Params))
+	midValues := make([]int64, len(s.Params))
+	for i, p := range s.Params {
+		maxValues[i] = p.Max
+		midValues[i] = p.Min + (p.Max-p.Min)/2
+	}
+	checks = append(checks, maxValues, midValues)
+	for i := range s.Params {
+		for j := i + 1; j < len(s.Params); j++ {
+			if s.Params[i].Max == s.Params[i].Min || s.Params[j].Max == s.Params[j].Min {
+				continue
+			}
+			values := append([]int64(nil), base...)
+			values[i]++
+			values[j]++
+			checks = append(checks, values)
+		}
+	}
+	for _, values := range checks {
+		result, err := s.Result(values)
+		if err != nil {
+			return nil, err
+		}
+		if result != model.Eval(values) {
+			return nil, nil
+		}
+	}
+	return model, nil
+}
+
+// solveLinear finds values satisfying the model, trying each value of every param but the last which affects
+// the result and calculating that one. Solutions are checked by running the program.
+func (s *InputSearch) solveLinear(model *LinearModel) ([]int64, error) {
+	solved := -1
+	for i, c := range model.Coefficients {
+		if c != 0 {
+			solved = i
+		}
+	}
+	if solved < 0 && model.Constant != s.Want {
+		// no param affects the result
+		return nil, ErrNoSolution
+	}
+
+	var solution []int64
+	var runErr error
+	s.eachValues(solved, func(values []int64) bool {
+		if solved >= 0 {
+			rest := s.Want - model.Eval(values)
+			c := model.Coefficients[solved]
+			if rest%c != 0 {
+				return true
+			}
+			values[solved] = rest / c
+			if values[solved] < s.Params[solved].Min || values[solved] > s.Params[solved].Max {
+				return true
+			}
+		}
+		result, err := s.Result(values)
+		if err != nil {
+			runErr = err
+			return false
+		}
+		if result == s.Want {
+			solution = append([]int64(nil), values...)
+			return false
+		}
+		return true
+	})
+	if runErr != nil {
+		return nil, runErr
+	}
+	if solution == nil {
+		return nil, ErrNoSolution
+	}
+	return solution, nil
+}
+
+// eachValues calls fn with every combination of param values in order, the skipped param is left as zero
+// for the caller to set. Iteration stops if fn returns false.
+func (s *InputSearch) eachValues(skip int, fn func(values []int64) bool) {
+	values := make([]int64, len(s.Params))
+	for i, p := range s.Params {
+		if i != skip {
+			values[i] = p.Min
+		}
+	}
+	for {
+		if !fn(append([]int64(nil), values...)) {
+			return
+		}
+		i := len(s.Params) - 1
+		for ; i >= 0; i-- {
+			if i == skip {
+				continue
+			}
+			if values[i] < s.Params[i].Max {
+				values[i]++
+				break
+			}
+			values[i] = s.Params[i].Min
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// Find runs the program with every combination of param values across a pool of workers, returning the
+// first solution in order of the params
+func (s *InputSearch) Find() ([]int64, error) {
+	return s.FindContext(context.Background())
+}
+
+// FindContext searches like Find, stopping when the context is done
+func (s *InputSearch) FindContext(ctx context.Context) ([]int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := s.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	type job struct {
+		index  int
+		values []int64
+	}
+	var mu sync.Mutex
+	best := -1
+	var solution []int64
+	var firstErr error
+	// found reports if a solution earlier than the index has been found, later values need not be tried
+	found := func(index int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return best >= 0 && best < index
+	}
+
+	jobs := make(chan job)
+	go func() {
+		defer close(jobs)
+		index := 0
+		s.eachValues(-1, func(values []int64) bool {
+			if found(index) {
+				return false
+			}
+			select {
+			case jobs <- job{index: index, values: values}:
+				index++
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if found(j.index) {
+					continue
+				}
+				result, err := s.result(ctx, j.values)
+				mu.Lock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = err
+					}
+					cancel()
+				case result == s.Want && (best < 0 || j.index < best):
+					best, solution = j.index, j.values
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if solution != nil {
+		return solution, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ErrNoSolution
+}
@@ -0,0 +1,82 @@
+package intcode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputSearch(t *testing.T) {
+	gravityAssist := readProgram(t, "../day02/input.txt")
+	nounVerb := []Param{{Addr: 1, Min: 0, Max: 99}, {Addr: 2, Min: 0, Max: 99}}
+
+	tt := map[string]struct {
+		inputData string
+		want      int64
+		params    []Param
+		expLinear bool
+		expValues []int64
+	}{
+		"day 2": {inputData: gravityAssist, want: 19690720, params: nounVerb, expLinear: true, expValues: []int64{56, 96}},
+		// Multiplies address 9 by address 10
+		"product": {inputData: "2,9,10,0,99,0,0,0,0,0,0", want: 42, params: []Param{{Addr: 9, Min: 2, Max: 9}, {Addr: 10, Min: 2, Max: 9}}, expValues: []int64{6, 7}},
+		// Adds three times address 13 to address 14, less seven
+		"linear with offset": {inputData: "1002,13,3,15,1,15,14,0,1001,0,-7,0,99,0,0,0", want: 30, params: []Param{{Addr: 13, Min: 10, Max: 20}, {Addr: 14, Min: -5, Max: 5}}, expLinear: true, expValues: []int64{11, 4}},
+		// Adds address 20 to address 21, and 100 more when their product is six which no sampled values give
+		"non linear between samples": {inputData: "1,20,21,0,2,20,21,22,1008,22,6,22,1006,22,19,1001,0,100,0,99,0,0,0", want: 105, params: []Param{{Addr: 20, Min: 0, Max: 3}, {Addr: 21, Min: 0, Max: 3}}, expLinear: true, expValues: []int64{2, 3}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			search, err := NewInputSearch(tc.inputData, 0, tc.want, tc.params...)
+			require.NoError(t, err)
+
+			model, err := search.Linear()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expLinear, model != nil)
+
+			values, err := search.Solve()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expValues, values)
+
+			values, err = search.Find()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expValues, values)
+		})
+	}
+}
+
+func TestInputSearchLinearModel(t *testing.T) {
+	search, err := NewInputSearch("1002,13,3,15,1,15,14,0,1001,0,-7,0,99,0,0,0", 0, 0, Param{Addr: 13, Min: 0, Max: 10}, Param{Addr: 14, Min: 0, Max: 10})
+	require.NoError(t, err)
+	model, err := search.Linear()
+	require.NoError(t, err)
+	assert.Equal(t, &LinearModel{Constant: -7, Coefficients: []int64{3, 1}}, model)
+	assert.Equal(t, int64(8), model.Eval([]int64{4, 3}))
+}
+
+func TestInputSearchErrors(t *testing.T) {
+	search, err := NewInputSearch("1,0,0,0,99", 0, 1000, Param{Addr: 1, Min: 0, Max: 4})
+	require.NoError(t, err)
+	_, err = search.Solve()
+	assert.Equal(t, ErrNoSolution, err)
+	_, err = search.Find()
+	assert.Equal(t, ErrNoSolution, err)
+
+	_, err = search.Result(nil)
+	assert.EqualError(t, err, "incorrect number of values provided (got 0, want 1)")
+
+	// Jumps to the address given by the param, looping forever at zero
+	search, err = NewInputSearch("1105,1,0,99", 0, 1105, Param{Addr: 2, Min: 0, Max: 3})
+	require.NoError(t, err)
+	search.MaxInstructions = 100
+	_, err = search.Find()
+	assert.ErrorIs(t, err, ErrInstructionBudget)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = search.FindContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,152 @@
+package intcode
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Input supplies the values read by input instructions. ErrInputClosed should be returned once no more
+// values will be provided.
+type Input interface {
+	ReadInput() (int64, error)
+}
+
+// Output receives the values written by output instructions. If the output also implements io.Closer it
+// is closed when Run returns after the program halts.
+type Output interface {
+	WriteOutput(val int64) error
+}
+
+// ContextInput is an Input which can stop waiting for a value when the run is cancelled
+type ContextInput interface {
+	Input
+	ReadInputContext(ctx context.Context) (int64, error)
+}
+
+// ContextOutput is an Output which can stop waiting to send a value when the run is cancelled
+type ContextOutput interface {
+	Output
+	WriteOutputContext(ctx context.Context, val int64) error
+}
+
+// InputFunc adapts a function to an Input
+type InputFunc func() (int64, error)
+
+// ReadInput calls the function
+func (f InputFunc) ReadInput() (int64, error) {
+	return f()
+}
+
+// OutputFunc adapts a function to an Output
+type OutputFunc func(val int64) error
+
+// WriteOutput calls the function
+func (f OutputFunc) WriteOutput(val int64) error {
+	return f(val)
+}
+
+type channelInput struct {
+	ch <-chan int64
+}
+
+// ChannelInput reads input values from a channel, blocking until one is sent
+func ChannelInput(ch <-chan int64) Input {
+	return &channelInput{ch: ch}
+}
+
+func (i *channelInput) ReadInput() (int64, error) {
+	return i.ReadInputContext(context.Background())
+}
+
+func (i *channelInput) ReadInputContext(ctx context.Context) (int64, error) {
+	select {
+	case val, ok := <-i.ch:
+		if !ok {
+			return 0, ErrInputClosed
+		}
+		return val, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+type channelOutput struct {
+	ch chan<- int64
+}
+
+// ChannelOutput sends output values on a channel, the channel is closed once the program halts
+func ChannelOutput(ch chan<- int64) Output {
+	return &channelOutput{ch: ch}
+}
+
+func (o *channelOutput) WriteOutput(val int64) error {
+	return o.WriteOutputContext(context.Background(), val)
+}
+
+func (o *channelOutput) WriteOutputContext(ctx context.Context, val int64) error {
+	select {
+	case o.ch <- val:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (o *channelOutput) Close() error {
+	close(o.ch)
+	return nil
+}
+
+type scannerInput struct {
+	scanner *bufio.Scanner
+}
+
+// ScannerInput reads one integer per scanned token, typically a line of stdin
+func ScannerInput(scanner *bufio.Scanner) Input {
+	return &scannerInput{scanner: scanner}
+}
+
+func (i *scannerInput) ReadInput() (int64, error) {
+	if !i.scanner.Scan() {
+		if err := i.scanner.Err(); err != nil {
+			return 0, errors.Wrap(err, "unable to scan input")
+		}
+		return 0, ErrInputClosed
+	}
+	val, err := strconv.ParseInt(strings.TrimSpace(i.scanner.Text()), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to parse input")
+	}
+	return val, nil
+}
+
+type sliceInput struct {
+	values []int64
+}
+
+// SliceInput provides a fixed list of input values
+func SliceInput(values ...int64) Input {
+	return &sliceInput{values: values}
+}
+
+func (i *sliceInput) ReadInput() (int64, error) {
+	if len(i.values) == 0 {
+		return 0, ErrInputClosed
+	}
+	val := i.values[0]
+	i.values = i.values[1:]
+	return val, nil
+}
+
+// StringInput provides each byte of the string as an input value, as used by ASCII programs
+func StringInput(s string) Input {
+	values := make([]int64, len(s))
+	for i := 0; i < len(s); i++ {
+		values[i] = int64(s[i])
+	}
+	return SliceInput(values...)
+}
@@ -0,0 +1,138 @@
+package intcode
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Echoes every input value until the input is closed
+const echoProgram = "3,9,4,9,1105,1,0,99,99,0"
+
+func TestInputAdapters(t *testing.T) {
+	tt := map[string]struct {
+		input  func() Input
+		output []int64
+	}{
+		"slice":   {input: func() Input { return SliceInput(1, -2, 300) }, output: []int64{1, -2, 300}},
+		"string":  {input: func() Input { return StringInput("hi\n") }, output: []int64{'h', 'i', '\n'}},
+		"scanner": {input: func() Input { return ScannerInput(bufio.NewScanner(strings.NewReader("4\n5\n"))) }, output: []int64{4, 5}},
+		"channel": {
+			input: func() Input {
+				ch := make(chan int64, 2)
+				ch <- 7
+				ch <- 8
+				close(ch)
+				return ChannelInput(ch)
+			},
+			output: []int64{7, 8},
+		},
+		"callback": {
+			input: func() Input {
+				next := int64(0)
+				return InputFunc(func() (int64, error) {
+					if next == 3 {
+						return 0, ErrInputClosed
+					}
+					next++
+					return next * 10, nil
+				})
+			},
+			output: []int64{10, 20, 30},
+		},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			var received []int64
+			out := OutputFunc(func(val int64) error {
+				received = append(received, val)
+				return nil
+			})
+			testComp, err := NewIOComputer(echoProgram, tc.input(), out)
+			require.NoError(t, err)
+			testComp.DisableLog = true
+			testComp.DisableOutLog = true
+
+			err = testComp.Run()
+			assert.ErrorIs(t, err, ErrInputClosed)
+			assert.Equal(t, tc.output, testComp.Outputs())
+			assert.Equal(t, tc.output, received)
+		})
+	}
+}
+
+func TestChannelOutputClosedOnExit(t *testing.T) {
+	out := make(chan int64, 2)
+	testComp, err := NewIOComputer("104,1,104,2,99", nil, ChannelOutput(out))
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+	require.NoError(t, testComp.Run())
+
+	var received []int64
+	for v := range out {
+		received = append(received, v)
+	}
+	assert.Equal(t, []int64{1, 2}, received)
+}
+
+func TestOutputError(t *testing.T) {
+	errFull := errors.New("output full")
+	testComp, err := NewIOComputer("104,1,104,2,99", nil, OutputFunc(func(val int64) error {
+		if val == 2 {
+			return errFull
+		}
+		return nil
+	}))
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+
+	err = testComp.Run()
+	var execErr *ExecutionError
+	require.ErrorAs(t, err, &execErr)
+	assert.Equal(t, 2, execErr.InsPtr)
+	assert.ErrorIs(t, err, errFull)
+}
+
+func TestSetInput(t *testing.T) {
+	testComp, err := NewComputer("3,0,4,0,99", nil)
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+	testComp.SetInput(SliceInput(42))
+
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, []int64{42}, testComp.Outputs())
+}
+
+func TestChannelOutputResumeAfterBudget(t *testing.T) {
+	// outputs 1 forever
+	out := make(chan int64, 10)
+	testComp, err := NewChannelComputer("104,1,1105,1,0", nil, out)
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+	testComp.MaxInstructions = 5
+
+	assert.ErrorIs(t, testComp.Run(), ErrInstructionBudget)
+	assert.ErrorIs(t, testComp.Run(), ErrInstructionBudget)
+	assert.Len(t, out, 5)
+
+	// the output is closed once a resumed program halts
+	out = make(chan int64, 2)
+	testComp, err = NewChannelComputer("104,1,104,2,99", nil, out)
+	require.NoError(t, err)
+	testComp.DisableOutLog = true
+	testComp.MaxInstructions = 1
+	assert.ErrorIs(t, testComp.Run(), ErrInstructionBudget)
+	testComp.MaxInstructions = 0
+	require.NoError(t, testComp.Run())
+
+	var received []int64
+	for v := range out {
+		received = append(received, v)
+	}
+	assert.Equal(t, []int64{1, 2}, received)
+}
@@ -0,0 +1,121 @@
+package intcode
+
+import (
+	"math/big"
+	"sync/atomic"
+)
+
+const pageSize = 1024
+
+// memoryPage is a block of memory values, once shared is set the page may be referenced by several memories
+// and is copied before being written to. The flag is only ever set, atomically, so that memories can be
+// cloned concurrently.
+type memoryPage struct {
+	values [pageSize]int64
+	shared int32
+}
+
+// memory is a sparse store of int code values, split into fixed size pages which are allocated when first
+// written to. Pages are shared between clones and only copied when one of the clones writes to them.
+//
+// Values outside the int64 range, only stored by computers using BigArithmetic, are kept in bigCells and
+// saturated to the nearest int64 in their page.
+type memory struct {
+	pages    map[int]*memoryPage
+	bigCells map[int]*big.Int
+	size     int
+}
+
+func newMemory(values []int64) *memory {
+	m := &memory{
+		pages: make(map[int]*memoryPage),
+	}
+	for addr, val := range values {
+		m.set(addr, val)
+	}
+	m.size = len(values)
+	return m
+}
+
+// get reads the value at the address, untouched memory reads as zero
+func (m *memory) get(addr int) int64 {
+	p, ok := m.pages[addr/pageSize]
+	if !ok {
+		return 0
+	}
+	return p.values[addr%pageSize]
+}
+
+// set writes the value at the address, allocating or copying the page if required
+func (m *memory) set(addr int, val int64) {
+	pageNum := addr / pageSize
+	p, ok := m.pages[pageNum]
+	switch {
+	case !ok:
+		p = &memoryPage{}
+		m.pages[pageNum] = p
+	case atomic.LoadInt32(&p.shared) != 0:
+		p = &memoryPage{values: p.values}
+		m.pages[pageNum] = p
+	}
+	p.values[addr%pageSize] = val
+	if m.bigCells != nil {
+		delete(m.bigCells, addr)
+	}
+
+	if addr >= m.size {
+		m.size = addr + 1
+	}
+}
+
+// getBig gives the exact value at the address if it is outside the int64 range, otherwise nil
+func (m *memory) getBig(addr int) *big.Int {
+	if m.bigCells == nil {
+		return nil
+	}
+	return m.bigCells[addr]
+}
+
+// setBig writes a value of any size at the address, stored values are never modified so the value is copied
+func (m *memory) setBig(addr int, val *big.Int) {
+	if val.IsInt64() {
+		m.set(addr, val.Int64())
+		return
+	}
+	m.set(addr, saturate(val))
+	if m.bigCells == nil {
+		m.bigCells = make(map[int]*big.Int)
+	}
+	m.bigCells[addr] = new(big.Int).Set(val)
+}
+
+// hasBig reports if any value outside the int64 range is stored
+func (m *memory) hasBig() bool {
+	return len(m.bigCells) != 0
+}
+
+// clone makes a copy of the memory which shares all pages, both copies copy a page before writing to it.
+// Only the pages' shared flags are changed, so a memory can be cloned by several goroutines at once as long
+// as none of them writes to it.
+func (m *memory) clone() *memory {
+	cpy := &memory{
+		pages: make(map[int]*memoryPage, len(m.pages)),
+		size:  m.size,
+	}
+	for pageNum, p := range m.pages {
+		atomic.StoreInt32(&p.shared, 1)
+		cpy.pages[pageNum] = p
+	}
+	if m.hasBig() {
+		cpy.bigCells = make(map[int]*big.Int, len(m.bigCells))
+		for addr, val := range m.bigCells {
+			cpy.bigCells[addr] = val
+		}
+	}
+	return cpy
+}
+
+// len is the number of addresses from zero up to the highest address written
+func (m *memory) len() int {
+	return m.size
+}
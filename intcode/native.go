@@ -0,0 +1,161 @@
+package intcode
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// nativePollInterval is how many blocks a compiled program enters between checks of its context
+const nativePollInterval = 1024
+
+// NativeFunc is a program compiled to Go by Compile
+type NativeFunc func(n *Native) error
+
+// Native is the state of a computer running a compiled program. Its methods are called by the generated
+// code and are not intended for use elsewhere.
+type Native struct {
+	c     *Computer
+	ctx   context.Context
+	code  []bool
+	steps int
+	polls int
+}
+
+// RunNative runs a compiled program, which must have been compiled from the program loaded in the computer.
+// The interpreter is used instead while the computer is instrumented or holds values outside the int64
+// range, and takes over if the program writes to its own code or jumps to an address which was not
+// compiled. Only I/O and halting are logged.
+func (c *Computer) RunNative(fn NativeFunc) error {
+	return c.RunNativeContext(context.Background(), fn)
+}
+
+// RunNativeContext runs a compiled program until it halts or the context is done, the context is checked
+// periodically rather than before every instruction
+func (c *Computer) RunNativeContext(ctx context.Context, fn NativeFunc) error {
+	defer c.closeOutput()
+	c.ctx = ctx
+	defer func() { c.ctx = nil }()
+
+	if c.instrumented() || c.Arithmetic != WrapArithmetic || c.memory.hasBig() {
+		return c.run(ctx, 0)
+	}
+	return fn(&Native{c: c, ctx: ctx})
+}
+
+// Start gives the address execution starts from
+func (n *Native) Start() int {
+	return n.c.insPtr
+}
+
+// Protect marks addresses from start up to end as compiled code, writing to them hands execution to the
+// interpreter. It reports false if memory no longer holds the code which was compiled, so the program
+// should be interpreted instead.
+func (n *Native) Protect(start, end int, hash uint64) bool {
+	if codeHash(n.c.memory, start, end) != hash {
+		return false
+	}
+	if end > len(n.code) {
+		code := make([]bool, end)
+		copy(code, n.code)
+		n.code = code
+	}
+	for addr := start; addr < end; addr++ {
+		n.code[addr] = true
+	}
+	return true
+}
+
+// Enter counts the instructions of a block about to run, reporting false if they would exceed the
+// instruction budget or the context is done so that the interpreter should run them instead
+func (n *Native) Enter(count int) bool {
+	if max := n.c.MaxInstructions; max > 0 && n.steps+count > max {
+		return false
+	}
+	if n.polls%nativePollInterval == 0 && n.ctx.Err() != nil {
+		return false
+	}
+	n.polls++
+	n.steps += count
+	n.c.executed += count
+	return true
+}
+
+// Base gives the relative base
+func (n *Native) Base() int {
+	return n.c.relativeBase
+}
+
+// Shift adjusts the relative base
+func (n *Native) Shift(shift int64) {
+	n.c.relativeBase += int(shift)
+}
+
+// Load reads the value at an address, which must not be negative
+func (n *Native) Load(addr int) int64 {
+	return n.c.memory.get(addr)
+}
+
+// Store writes the value at an address, which must not be negative, reporting if it was compiled code
+func (n *Native) Store(addr int, val int64) bool {
+	n.c.memory.set(addr, val)
+	if n.c.decoded != nil {
+		n.c.decoded.invalidate(addr)
+	}
+	return addr < len(n.code) && n.code[addr]
+}
+
+// Input reads the next input for the instruction at pc, skipped is the number of instructions of the
+// block which will not run if it fails
+func (n *Native) Input(pc, skipped int) (int64, error) {
+	val, err := n.c.readInput()
+	if err != nil {
+		n.skip(skipped)
+		return 0, n.c.executionError(pc, err)
+	}
+	return val, nil
+}
+
+// Output writes a value for the instruction at pc, skipped is the number of instructions of the block which
+// will not run if it fails
+func (n *Native) Output(pc, skipped int, val int64) error {
+	if err := n.c.writeOutput(val); err != nil {
+		n.skip(skipped)
+		return n.c.executionError(pc, err)
+	}
+	return nil
+}
+
+// Halt stops the program at the halt instruction at pc
+func (n *Native) Halt(pc int) error {
+	n.c.insPtr = pc + 1
+	n.c.terminated = true
+	n.c.logOutf("%sHALT%s\n", Red, Reset)
+	return nil
+}
+
+// Resume hands execution to the interpreter at pc, skipped is the number of instructions of the block
+// which have not run
+func (n *Native) Resume(pc, skipped int) error {
+	n.skip(skipped)
+	n.c.insPtr = pc
+	return n.c.run(n.ctx, n.steps)
+}
+
+// skip uncounts instructions entered but not run
+func (n *Native) skip(count int) {
+	n.steps -= count
+	n.c.executed -= count
+}
+
+// codeHash hashes the values from start up to end, so compiled code can check it is running the program it
+// was compiled from
+func codeHash(m *memory, start, end int) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for addr := start; addr < end; addr++ {
+		binary.LittleEndian.PutUint64(buf[:], uint64(m.get(addr)))
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
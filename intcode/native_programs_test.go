@@ -0,0 +1,1607 @@
+// Code generated by intcode.Compile. DO NOT EDIT.
+
+package intcode
+
+// nativeQExample runs a compiled Intcode program of 3 instructions
+func nativeQExample(n *Native) error {
+	if !n.Protect(0, 9, 0xf7e22f2dd646858d) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [9], [10], [3]
+			n.Store(3, n.Load(9)+n.Load(10))
+			return n.Resume(4, 2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeExample1 runs a compiled Intcode program of 2 instructions
+func nativeExample1(n *Native) error {
+	if !n.Protect(0, 5, 0xde83cb7efcdae427) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [0], [0], [0]
+			n.Store(0, n.Load(0)+n.Load(0))
+			return n.Resume(4, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeExample2 runs a compiled Intcode program of 2 instructions
+func nativeExample2(n *Native) error {
+	if !n.Protect(0, 5, 0x6003dfc6ec6e6e04) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: MULT [3], [0], [3]
+			n.Store(3, n.Load(3)*n.Load(0))
+			return n.Resume(4, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeExample3 runs a compiled Intcode program of 2 instructions
+func nativeExample3(n *Native) error {
+	if !n.Protect(0, 5, 0xf763f868efcd4201) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: MULT [4], [4], [5]
+			n.Store(5, n.Load(4)*n.Load(4))
+			// 4: HALT
+			return n.Halt(4)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeExample4 runs a compiled Intcode program of 2 instructions
+func nativeExample4(n *Native) error {
+	if !n.Protect(0, 5, 0xc28abbc6da405103) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [1], [1], [4]
+			n.Store(4, n.Load(1)+n.Load(1))
+			return n.Resume(4, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeSimpleInput runs a compiled Intcode program of 2 instructions
+func nativeSimpleInput(n *Native) error {
+	if !n.Protect(0, 3, 0x16813db7cf3c0ba6) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [3]
+			in0, err := n.Input(0, 2)
+			if err != nil {
+				return err
+			}
+			n.Store(3, in0)
+			// 2: HALT
+			return n.Halt(2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeImmediateAddition runs a compiled Intcode program of 2 instructions
+func nativeImmediateAddition(n *Native) error {
+	if !n.Protect(0, 5, 0x40492d846769ff56) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [0], #200, [0]
+			n.Store(0, n.Load(0)+200)
+			return n.Resume(4, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeEcho runs a compiled Intcode program of 3 instructions
+func nativeEcho(n *Native) error {
+	if !n.Protect(0, 5, 0x5321c00c11b4761) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [0]
+			in0, err := n.Input(0, 3)
+			if err != nil {
+				return err
+			}
+			n.Store(0, in0)
+			return n.Resume(2, 2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativePositionEqual runs a compiled Intcode program of 4 instructions
+func nativePositionEqual(n *Native) error {
+	if !n.Protect(0, 9, 0x28b2ea5f27fe0223) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [9]
+			in0, err := n.Input(0, 4)
+			if err != nil {
+				return err
+			}
+			n.Store(9, in0)
+			// 2: EQ [9], [10], [9]
+			v2 := int64(0)
+			if n.Load(9) == n.Load(10) {
+				v2 = 1
+			}
+			n.Store(9, v2)
+			// 6: OUT [9]
+			if err := n.Output(6, 2, n.Load(9)); err != nil {
+				return err
+			}
+			// 8: HALT
+			return n.Halt(8)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativePositionLessThan runs a compiled Intcode program of 4 instructions
+func nativePositionLessThan(n *Native) error {
+	if !n.Protect(0, 9, 0x52c21ee69a5598cc) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [9]
+			in0, err := n.Input(0, 4)
+			if err != nil {
+				return err
+			}
+			n.Store(9, in0)
+			// 2: LT [9], [10], [9]
+			v2 := int64(0)
+			if n.Load(9) < n.Load(10) {
+				v2 = 1
+			}
+			n.Store(9, v2)
+			// 6: OUT [9]
+			if err := n.Output(6, 2, n.Load(9)); err != nil {
+				return err
+			}
+			// 8: HALT
+			return n.Halt(8)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeAbsoluteEqual runs a compiled Intcode program of 4 instructions
+func nativeAbsoluteEqual(n *Native) error {
+	if !n.Protect(0, 9, 0x44249d19dadf599a) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [3]
+			in0, err := n.Input(0, 4)
+			if err != nil {
+				return err
+			}
+			n.Store(3, in0)
+			return n.Resume(2, 3)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeAbsoluteLessThan runs a compiled Intcode program of 4 instructions
+func nativeAbsoluteLessThan(n *Native) error {
+	if !n.Protect(0, 9, 0x28cf31300a8ecbb5) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [3]
+			in0, err := n.Input(0, 4)
+			if err != nil {
+				return err
+			}
+			n.Store(3, in0)
+			return n.Resume(2, 3)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativePositionJump runs a compiled Intcode program of 5 instructions
+func nativePositionJump(n *Native) error {
+	if !n.Protect(0, 12, 0x2519749885e75eca) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [12]
+			in0, err := n.Input(0, 2)
+			if err != nil {
+				return err
+			}
+			n.Store(12, in0)
+			// 2: JF [12], [15]
+			if n.Load(12) == 0 {
+				target := n.Load(15)
+				if target < 0 {
+					return n.Resume(2, 1)
+				}
+				pc = int(target)
+				continue
+			}
+			fallthrough
+		case 5:
+			if !n.Enter(3) {
+				return n.Resume(5, 0)
+			}
+			// 5: ADD [13], [14], [13]
+			n.Store(13, n.Load(13)+n.Load(14))
+			// 9: OUT [13]
+			if err := n.Output(9, 2, n.Load(13)); err != nil {
+				return err
+			}
+			// 11: HALT
+			return n.Halt(11)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeImmediateJump runs a compiled Intcode program of 4 instructions
+func nativeImmediateJump(n *Native) error {
+	if !n.Protect(0, 5, 0x92af96095ea2f689) {
+		return n.Resume(n.Start(), 0)
+	}
+	if !n.Protect(9, 12, 0x1c0cc3ede0c51a4e) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [3]
+			in0, err := n.Input(0, 2)
+			if err != nil {
+				return err
+			}
+			n.Store(3, in0)
+			return n.Resume(2, 1)
+		case 9:
+			if !n.Enter(2) {
+				return n.Resume(9, 0)
+			}
+			// 9: OUT [12]
+			if err := n.Output(9, 2, n.Load(12)); err != nil {
+				return err
+			}
+			// 11: HALT
+			return n.Halt(11)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeLessEqualGreater runs a compiled Intcode program of 15 instructions
+func nativeLessEqualGreater(n *Native) error {
+	if !n.Protect(0, 19, 0x210071adf21b24a1) {
+		return n.Resume(n.Start(), 0)
+	}
+	if !n.Protect(22, 45, 0xc419db103f265b2b) {
+		return n.Resume(n.Start(), 0)
+	}
+	if !n.Protect(46, 47, 0xa71ba05c90f2c806) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [21]
+			in0, err := n.Input(0, 3)
+			if err != nil {
+				return err
+			}
+			n.Store(21, in0)
+			// 2: EQ [21], #8, [20]
+			v2 := int64(0)
+			if n.Load(21) == 8 {
+				v2 = 1
+			}
+			n.Store(20, v2)
+			// 6: JT [20], #22
+			if n.Load(20) != 0 {
+				pc = 22
+				continue
+			}
+			fallthrough
+		case 9:
+			if !n.Enter(2) {
+				return n.Resume(9, 0)
+			}
+			// 9: LT #8, [21], [20]
+			v9 := int64(0)
+			if 8 < n.Load(21) {
+				v9 = 1
+			}
+			n.Store(20, v9)
+			// 13: JF [20], #31
+			if n.Load(20) == 0 {
+				pc = 31
+				continue
+			}
+			fallthrough
+		case 16:
+			if !n.Enter(1) {
+				return n.Resume(16, 0)
+			}
+			// 16: JF #0, #36
+			pc = 36
+			continue
+		case 22:
+			if !n.Enter(3) {
+				return n.Resume(22, 0)
+			}
+			// 22: MULT [21], #125, [20]
+			n.Store(20, n.Load(21)*125)
+			// 26: OUT [20]
+			if err := n.Output(26, 2, n.Load(20)); err != nil {
+				return err
+			}
+			// 28: JT #1, #46
+			pc = 46
+			continue
+		case 31:
+			if !n.Enter(2) {
+				return n.Resume(31, 0)
+			}
+			// 31: OUT #999
+			if err := n.Output(31, 2, 999); err != nil {
+				return err
+			}
+			// 33: JT #1, #46
+			pc = 46
+			continue
+		case 36:
+			if !n.Enter(3) {
+				return n.Resume(36, 0)
+			}
+			// 36: ADD #1000, #1, [20]
+			n.Store(20, 1001)
+			// 40: OUT [20]
+			if err := n.Output(40, 2, n.Load(20)); err != nil {
+				return err
+			}
+			// 42: JT #1, #46
+			pc = 46
+			continue
+		case 46:
+			if !n.Enter(1) {
+				return n.Resume(46, 0)
+			}
+			// 46: HALT
+			return n.Halt(46)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeRelativeInput runs a compiled Intcode program of 4 instructions
+func nativeRelativeInput(n *Native) error {
+	if !n.Protect(0, 7, 0x6f3de30127552de4) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: ARB #-1
+			n.Shift(-1)
+			// 2: IN rb+1
+			if n.Base()+1 < 0 {
+				return n.Resume(2, 3)
+			}
+			in2, err := n.Input(2, 3)
+			if err != nil {
+				return err
+			}
+			if n.Store(n.Base()+1, in2) {
+				return n.Resume(4, 2)
+			}
+			// 4: OUT rb+1
+			if n.Base()+1 < 0 {
+				return n.Resume(4, 2)
+			}
+			if err := n.Output(4, 2, n.Load(n.Base()+1)); err != nil {
+				return err
+			}
+			// 6: HALT
+			return n.Halt(6)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeQuine runs a compiled Intcode program of 6 instructions
+func nativeQuine(n *Native) error {
+	if !n.Protect(0, 16, 0x4273679a0ad53bd9) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(5) {
+				return n.Resume(0, 0)
+			}
+			// 0: ARB #1
+			n.Shift(1)
+			// 2: OUT rb-1
+			if n.Base()-1 < 0 {
+				return n.Resume(2, 4)
+			}
+			if err := n.Output(2, 4, n.Load(n.Base()-1)); err != nil {
+				return err
+			}
+			// 4: ADD [100], #1, [100]
+			n.Store(100, n.Load(100)+1)
+			// 8: EQ [100], #16, [101]
+			v8 := int64(0)
+			if n.Load(100) == 16 {
+				v8 = 1
+			}
+			n.Store(101, v8)
+			// 12: JF [101], #0
+			if n.Load(101) == 0 {
+				pc = 0
+				continue
+			}
+			fallthrough
+		case 15:
+			if !n.Enter(1) {
+				return n.Resume(15, 0)
+			}
+			// 15: HALT
+			return n.Halt(15)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeLargeMultiply runs a compiled Intcode program of 3 instructions
+func nativeLargeMultiply(n *Native) error {
+	if !n.Protect(0, 7, 0x6e091a4feb231538) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: MULT #34915192, #34915192, [7]
+			n.Store(7, 1219070632396864)
+			// 4: OUT [7]
+			if err := n.Output(4, 2, n.Load(7)); err != nil {
+				return err
+			}
+			// 6: HALT
+			return n.Halt(6)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeLargeOutput runs a compiled Intcode program of 2 instructions
+func nativeLargeOutput(n *Native) error {
+	if !n.Protect(0, 3, 0xaa6050c37cbbb6ca) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: OUT #1125899906842624
+			if err := n.Output(0, 2, 1125899906842624); err != nil {
+				return err
+			}
+			// 2: HALT
+			return n.Halt(2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeWriteFar runs a compiled Intcode program of 3 instructions
+func nativeWriteFar(n *Native) error {
+	if !n.Protect(0, 7, 0x7384a9e40d90b970) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD #5, #6, [250000]
+			n.Store(250000, 11)
+			// 4: OUT [250000]
+			if err := n.Output(4, 2, n.Load(250000)); err != nil {
+				return err
+			}
+			// 6: HALT
+			return n.Halt(6)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeReadUntouched runs a compiled Intcode program of 2 instructions
+func nativeReadUntouched(n *Native) error {
+	if !n.Protect(0, 3, 0xa678fd5ff055e42f) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: OUT [1000000]
+			if err := n.Output(0, 2, n.Load(1000000)); err != nil {
+				return err
+			}
+			// 2: HALT
+			return n.Halt(2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeRelativeWriteFar runs a compiled Intcode program of 4 instructions
+func nativeRelativeWriteFar(n *Native) error {
+	if !n.Protect(0, 9, 0x154f52505b6db925) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: ARB #500000
+			n.Shift(500000)
+			// 2: ADD #2, #3, rb+7
+			if n.Base()+7 < 0 {
+				return n.Resume(2, 3)
+			}
+			if n.Store(n.Base()+7, 5) {
+				return n.Resume(6, 2)
+			}
+			// 6: OUT rb+7
+			if n.Base()+7 < 0 {
+				return n.Resume(6, 2)
+			}
+			if err := n.Output(6, 2, n.Load(n.Base()+7)); err != nil {
+				return err
+			}
+			// 8: HALT
+			return n.Halt(8)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeUnknownOpCode runs a compiled Intcode program of 2 instructions
+func nativeUnknownOpCode(n *Native) error {
+	if !n.Protect(0, 4, 0x7295d91aa94b524) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [0], [0], [0]
+			n.Store(0, n.Load(0)+n.Load(0))
+			return n.Resume(4, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeNegativePositionRead runs a compiled Intcode program of 2 instructions
+func nativeNegativePositionRead(n *Native) error {
+	if !n.Protect(0, 5, 0xe827f75fafbefddf) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [-1], [0], [0]
+			return n.Resume(0, 2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeNegativeRelativeRead runs a compiled Intcode program of 3 instructions
+func nativeNegativeRelativeRead(n *Native) error {
+	if !n.Protect(0, 5, 0x991ebbcaec7b0c7f) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: ARB #2
+			n.Shift(2)
+			// 2: OUT rb-3
+			if n.Base()-3 < 0 {
+				return n.Resume(2, 2)
+			}
+			if err := n.Output(2, 2, n.Load(n.Base()-3)); err != nil {
+				return err
+			}
+			// 4: HALT
+			return n.Halt(4)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeNegativeJumpTarget runs a compiled Intcode program of 2 instructions
+func nativeNegativeJumpTarget(n *Native) error {
+	if !n.Protect(0, 3, 0xdc283afd6f321583) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case -3:
+			if !n.Enter(1) {
+				return n.Resume(-3, 0)
+			}
+			// -3: invalid instruction
+			return n.Resume(-3, 1)
+		case 0:
+			if !n.Enter(1) {
+				return n.Resume(0, 0)
+			}
+			// 0: JT #1, #-3
+			return n.Resume(0, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeInvalidMode runs a compiled Intcode program of 1 instructions
+func nativeInvalidMode(n *Native) error {
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(1) {
+				return n.Resume(0, 0)
+			}
+			// 0: invalid instruction
+			return n.Resume(0, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeTooManyModes runs a compiled Intcode program of 1 instructions
+func nativeTooManyModes(n *Native) error {
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(1) {
+				return n.Resume(0, 0)
+			}
+			// 0: invalid instruction
+			return n.Resume(0, 1)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeInputTwice runs a compiled Intcode program of 3 instructions
+func nativeInputTwice(n *Native) error {
+	if !n.Protect(0, 5, 0x39bb214097928a46) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: IN [0]
+			in0, err := n.Input(0, 3)
+			if err != nil {
+				return err
+			}
+			n.Store(0, in0)
+			return n.Resume(2, 2)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeSelfModifying runs a compiled Intcode program of 5 instructions
+func nativeSelfModifying(n *Native) error {
+	if !n.Protect(0, 14, 0x8ec451f0501a56b) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(4) {
+				return n.Resume(0, 0)
+			}
+			// 0: OUT #0
+			if err := n.Output(0, 4, 0); err != nil {
+				return err
+			}
+			// 2: ADD [1], #1, [1]
+			n.Store(1, n.Load(1)+1)
+			return n.Resume(6, 2)
+		case 13:
+			if !n.Enter(1) {
+				return n.Resume(13, 0)
+			}
+			// 13: HALT
+			return n.Halt(13)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeHaltWithMode runs a compiled Intcode program of 2 instructions
+func nativeHaltWithMode(n *Native) error {
+	if !n.Protect(0, 5, 0xf9c3c0e6a81dee96) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(2) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD #1, #1, [5]
+			n.Store(5, 2)
+			// 4: HALT
+			return n.Halt(4)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeGravityAssist runs a compiled Intcode program of 40 instructions
+func nativeGravityAssist(n *Native) error {
+	if !n.Protect(0, 157, 0x8837b71273ff01cb) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(40) {
+				return n.Resume(0, 0)
+			}
+			// 0: ADD [12], [2], [3]
+			n.Store(3, n.Load(12)+n.Load(2))
+			return n.Resume(4, 39)
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
+
+// nativeBoost runs a compiled Intcode program of 133 instructions
+func nativeBoost(n *Native) error {
+	if !n.Protect(0, 63, 0xe1cdd3c267459319) {
+		return n.Resume(n.Start(), 0)
+	}
+	if !n.Protect(65, 362, 0xdc9bf0aad6181fe8) {
+		return n.Resume(n.Start(), 0)
+	}
+	if !n.Protect(904, 973, 0x51eeb5ec13c4d060) {
+		return n.Resume(n.Start(), 0)
+	}
+	for pc := n.Start(); ; {
+		switch pc {
+		case 0:
+			if !n.Enter(3) {
+				return n.Resume(0, 0)
+			}
+			// 0: MULT #34463338, #34463338, [63]
+			n.Store(63, 1187721666102244)
+			// 4: LT [63], #34463338, [63]
+			v4 := int64(0)
+			if n.Load(63) < 34463338 {
+				v4 = 1
+			}
+			n.Store(63, v4)
+			// 8: JT [63], #53
+			if n.Load(63) != 0 {
+				pc = 53
+				continue
+			}
+			fallthrough
+		case 11:
+			if !n.Enter(9) {
+				return n.Resume(11, 0)
+			}
+			// 11: ADD #0, #3, [1000]
+			n.Store(1000, 3)
+			// 15: ARB #988
+			n.Shift(988)
+			// 17: ARB rb+12
+			if n.Base()+12 < 0 {
+				return n.Resume(17, 7)
+			}
+			n.Shift(n.Load(n.Base() + 12))
+			// 19: ARB [1000]
+			n.Shift(n.Load(1000))
+			// 21: ARB rb+6
+			if n.Base()+6 < 0 {
+				return n.Resume(21, 5)
+			}
+			n.Shift(n.Load(n.Base() + 6))
+			// 23: ARB rb+3
+			if n.Base()+3 < 0 {
+				return n.Resume(23, 4)
+			}
+			n.Shift(n.Load(n.Base() + 3))
+			// 25: IN rb+0
+			if n.Base() < 0 {
+				return n.Resume(25, 3)
+			}
+			in25, err := n.Input(25, 3)
+			if err != nil {
+				return err
+			}
+			if n.Store(n.Base(), in25) {
+				return n.Resume(27, 2)
+			}
+			// 27: EQ [1000], #1, [63]
+			v27 := int64(0)
+			if n.Load(1000) == 1 {
+				v27 = 1
+			}
+			n.Store(63, v27)
+			// 31: JT [63], #65
+			if n.Load(63) != 0 {
+				pc = 65
+				continue
+			}
+			fallthrough
+		case 34:
+			if !n.Enter(2) {
+				return n.Resume(34, 0)
+			}
+			// 34: EQ [1000], #2, [63]
+			v34 := int64(0)
+			if n.Load(1000) == 2 {
+				v34 = 1
+			}
+			n.Store(63, v34)
+			// 38: JT [63], #904
+			if n.Load(63) != 0 {
+				pc = 904
+				continue
+			}
+			fallthrough
+		case 41:
+			if !n.Enter(2) {
+				return n.Resume(41, 0)
+			}
+			// 41: EQ [1000], #0, [63]
+			v41 := int64(0)
+			if n.Load(1000) == 0 {
+				v41 = 1
+			}
+			n.Store(63, v41)
+			// 45: JT [63], #58
+			if n.Load(63) != 0 {
+				pc = 58
+				continue
+			}
+			fallthrough
+		case 48:
+			if !n.Enter(3) {
+				return n.Resume(48, 0)
+			}
+			// 48: OUT [25]
+			if err := n.Output(48, 3, n.Load(25)); err != nil {
+				return err
+			}
+			// 50: OUT #0
+			if err := n.Output(50, 2, 0); err != nil {
+				return err
+			}
+			// 52: HALT
+			return n.Halt(52)
+		case 53:
+			if !n.Enter(3) {
+				return n.Resume(53, 0)
+			}
+			// 53: OUT [0]
+			if err := n.Output(53, 3, n.Load(0)); err != nil {
+				return err
+			}
+			// 55: OUT #0
+			if err := n.Output(55, 2, 0); err != nil {
+				return err
+			}
+			// 57: HALT
+			return n.Halt(57)
+		case 58:
+			if !n.Enter(3) {
+				return n.Resume(58, 0)
+			}
+			// 58: OUT [17]
+			if err := n.Output(58, 3, n.Load(17)); err != nil {
+				return err
+			}
+			// 60: OUT #0
+			if err := n.Output(60, 2, 0); err != nil {
+				return err
+			}
+			// 62: HALT
+			return n.Halt(62)
+		case 65:
+			if !n.Enter(32) {
+				return n.Resume(65, 0)
+			}
+			// 65: ADD #0, #30, [1016]
+			n.Store(1016, 30)
+			// 69: ADD #37, #0, [1005]
+			n.Store(1005, 37)
+			// 73: ADD #362, #0, [1023]
+			n.Store(1023, 362)
+			// 77: ADD #0, #20, [1014]
+			n.Store(1014, 20)
+			// 81: ADD #39, #0, [1013]
+			n.Store(1013, 39)
+			// 85: MULT #34, #1, [1007]
+			n.Store(1007, 34)
+			// 89: ADD #682, #0, [1027]
+			n.Store(1027, 682)
+			// 93: MULT #664, #1, [1025]
+			n.Store(1025, 664)
+			// 97: MULT #1, #655, [1028]
+			n.Store(1028, 655)
+			// 101: ADD #0, #26, [1002]
+			n.Store(1002, 26)
+			// 105: MULT #1, #38, [1015]
+			n.Store(1015, 38)
+			// 109: ADD #669, #0, [1024]
+			n.Store(1024, 669)
+			// 113: ADD #0, #28, [1017]
+			n.Store(1017, 28)
+			// 117: MULT #1, #21, [1000]
+			n.Store(1000, 21)
+			// 121: ADD #0, #27, [1012]
+			n.Store(1012, 27)
+			// 125: MULT #1, #29, [1008]
+			n.Store(1008, 29)
+			// 129: MULT #1, #23, [1019]
+			n.Store(1019, 23)
+			// 133: ADD #0, #24, [1011]
+			n.Store(1011, 24)
+			// 137: ADD #685, #0, [1026]
+			n.Store(1026, 685)
+			// 141: MULT #646, #1, [1029]
+			n.Store(1029, 646)
+			// 145: MULT #1, #369, [1022]
+			n.Store(1022, 369)
+			// 149: ADD #0, #31, [1003]
+			n.Store(1003, 31)
+			// 153: MULT #1, #36, [1001]
+			n.Store(1001, 36)
+			// 157: ADD #0, #0, [1020]
+			n.Store(1020, 0)
+			// 161: MULT #1, #35, [1009]
+			n.Store(1009, 35)
+			// 165: ADD #32, #0, [1010]
+			n.Store(1010, 32)
+			// 169: ADD #0, #1, [1021]
+			n.Store(1021, 1)
+			// 173: MULT #33, #1, [1004]
+			n.Store(1004, 33)
+			// 177: ADD #22, #0, [1006]
+			n.Store(1006, 22)
+			// 181: MULT #1, #25, [1018]
+			n.Store(1018, 25)
+			// 185: ARB #14
+			n.Shift(14)
+			// 187: JT rb+6, #197
+			if n.Base()+6 < 0 {
+				return n.Resume(187, 1)
+			}
+			if n.Load(n.Base()+6) != 0 {
+				pc = 197
+				continue
+			}
+			fallthrough
+		case 190:
+			if !n.Enter(2) {
+				return n.Resume(190, 0)
+			}
+			// 190: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			// 194: JT #1, #199
+			pc = 199
+			continue
+		case 197:
+			if !n.Enter(1) {
+				return n.Resume(197, 0)
+			}
+			// 197: OUT [187]
+			if err := n.Output(197, 1, n.Load(187)); err != nil {
+				return err
+			}
+			fallthrough
+		case 199:
+			if !n.Enter(4) {
+				return n.Resume(199, 0)
+			}
+			// 199: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 203: ARB #-4
+			n.Shift(-4)
+			// 205: LT #40, #39, rb+9
+			if n.Base()+9 < 0 {
+				return n.Resume(205, 2)
+			}
+			if n.Store(n.Base()+9, 0) {
+				return n.Resume(209, 1)
+			}
+			// 209: JT [1019], #219
+			if n.Load(1019) != 0 {
+				pc = 219
+				continue
+			}
+			fallthrough
+		case 212:
+			if !n.Enter(2) {
+				return n.Resume(212, 0)
+			}
+			// 212: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			// 216: JT #1, #221
+			pc = 221
+			continue
+		case 219:
+			if !n.Enter(1) {
+				return n.Resume(219, 0)
+			}
+			// 219: OUT [205]
+			if err := n.Output(219, 1, n.Load(205)); err != nil {
+				return err
+			}
+			fallthrough
+		case 221:
+			if !n.Enter(3) {
+				return n.Resume(221, 0)
+			}
+			// 221: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 225: ARB #9
+			n.Shift(9)
+			// 227: JF rb+1, #239
+			if n.Base()+1 < 0 {
+				return n.Resume(227, 1)
+			}
+			if n.Load(n.Base()+1) == 0 {
+				pc = 239
+				continue
+			}
+			fallthrough
+		case 230:
+			if !n.Enter(3) {
+				return n.Resume(230, 0)
+			}
+			// 230: OUT [227]
+			if err := n.Output(230, 3, n.Load(227)); err != nil {
+				return err
+			}
+			// 232: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			// 236: JF #0, #239
+			pc = 239
+			continue
+		case 239:
+			if !n.Enter(5) {
+				return n.Resume(239, 0)
+			}
+			// 239: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 243: ARB #-9
+			n.Shift(-9)
+			// 245: ADD #0, rb-8, [63]
+			if n.Base()-8 < 0 {
+				return n.Resume(245, 3)
+			}
+			n.Store(63, 0+n.Load(n.Base()-8))
+			// 249: EQ [63], #26, [63]
+			v249 := int64(0)
+			if n.Load(63) == 26 {
+				v249 = 1
+			}
+			n.Store(63, v249)
+			// 253: JT [63], #261
+			if n.Load(63) != 0 {
+				pc = 261
+				continue
+			}
+			fallthrough
+		case 256:
+			if !n.Enter(2) {
+				return n.Resume(256, 0)
+			}
+			// 256: OUT [245]
+			if err := n.Output(256, 2, n.Load(245)); err != nil {
+				return err
+			}
+			// 258: JF #0, #265
+			pc = 265
+			continue
+		case 261:
+			if !n.Enter(1) {
+				return n.Resume(261, 0)
+			}
+			// 261: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			fallthrough
+		case 265:
+			if !n.Enter(4) {
+				return n.Resume(265, 0)
+			}
+			// 265: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 269: ARB #-6
+			n.Shift(-6)
+			// 271: EQ #37, rb+1, [63]
+			if n.Base()+1 < 0 {
+				return n.Resume(271, 2)
+			}
+			v271 := int64(0)
+			if 37 == n.Load(n.Base()+1) {
+				v271 = 1
+			}
+			n.Store(63, v271)
+			// 275: JT [63], #287
+			if n.Load(63) != 0 {
+				pc = 287
+				continue
+			}
+			fallthrough
+		case 278:
+			if !n.Enter(3) {
+				return n.Resume(278, 0)
+			}
+			// 278: OUT [271]
+			if err := n.Output(278, 3, n.Load(271)); err != nil {
+				return err
+			}
+			// 280: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			// 284: JT #1, #287
+			pc = 287
+			continue
+		case 287:
+			if !n.Enter(4) {
+				return n.Resume(287, 0)
+			}
+			// 287: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 291: ARB #15
+			n.Shift(15)
+			// 293: EQ #41, #44, rb-2
+			if n.Base()-2 < 0 {
+				return n.Resume(293, 2)
+			}
+			if n.Store(n.Base()-2, 0) {
+				return n.Resume(297, 1)
+			}
+			// 297: JT [1017], #307
+			if n.Load(1017) != 0 {
+				pc = 307
+				continue
+			}
+			fallthrough
+		case 300:
+			if !n.Enter(2) {
+				return n.Resume(300, 0)
+			}
+			// 300: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			// 304: JF #0, #309
+			pc = 309
+			continue
+		case 307:
+			if !n.Enter(1) {
+				return n.Resume(307, 0)
+			}
+			// 307: OUT [293]
+			if err := n.Output(307, 1, n.Load(293)); err != nil {
+				return err
+			}
+			fallthrough
+		case 309:
+			if !n.Enter(4) {
+				return n.Resume(309, 0)
+			}
+			// 309: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 313: ARB #-16
+			n.Shift(-16)
+			// 315: LT rb+1, #34, [63]
+			if n.Base()+1 < 0 {
+				return n.Resume(315, 2)
+			}
+			v315 := int64(0)
+			if n.Load(n.Base()+1) < 34 {
+				v315 = 1
+			}
+			n.Store(63, v315)
+			// 319: JT [63], #327
+			if n.Load(63) != 0 {
+				pc = 327
+				continue
+			}
+			fallthrough
+		case 322:
+			if !n.Enter(2) {
+				return n.Resume(322, 0)
+			}
+			// 322: OUT [315]
+			if err := n.Output(322, 2, n.Load(315)); err != nil {
+				return err
+			}
+			// 324: JT #1, #331
+			pc = 331
+			continue
+		case 327:
+			if !n.Enter(1) {
+				return n.Resume(327, 0)
+			}
+			// 327: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			fallthrough
+		case 331:
+			if !n.Enter(4) {
+				return n.Resume(331, 0)
+			}
+			// 331: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 335: ARB #8
+			n.Shift(8)
+			// 337: EQ rb-9, #29, [63]
+			if n.Base()-9 < 0 {
+				return n.Resume(337, 2)
+			}
+			v337 := int64(0)
+			if n.Load(n.Base()-9) == 29 {
+				v337 = 1
+			}
+			n.Store(63, v337)
+			// 341: JT [63], #347
+			if n.Load(63) != 0 {
+				pc = 347
+				continue
+			}
+			fallthrough
+		case 344:
+			if !n.Enter(1) {
+				return n.Resume(344, 0)
+			}
+			// 344: JF #0, #353
+			pc = 353
+			continue
+		case 347:
+			if !n.Enter(2) {
+				return n.Resume(347, 0)
+			}
+			// 347: OUT [337]
+			if err := n.Output(347, 2, n.Load(337)); err != nil {
+				return err
+			}
+			// 349: ADD [64], #1, [64]
+			n.Store(64, n.Load(64)+1)
+			fallthrough
+		case 353:
+			if !n.Enter(3) {
+				return n.Resume(353, 0)
+			}
+			// 353: MULT [64], #2, [64]
+			n.Store(64, n.Load(64)*2)
+			// 357: ARB #4
+			n.Shift(4)
+			// 359: JT #1, rb+8
+			if n.Base()+8 < 0 {
+				return n.Resume(359, 1)
+			}
+			target := n.Load(n.Base() + 8)
+			if target < 0 {
+				return n.Resume(359, 1)
+			}
+			pc = int(target)
+			continue
+		case 904:
+			if !n.Enter(3) {
+				return n.Resume(904, 0)
+			}
+			// 904: MULT #27, #1, rb+1
+			if n.Base()+1 < 0 {
+				return n.Resume(904, 3)
+			}
+			if n.Store(n.Base()+1, 27) {
+				return n.Resume(908, 2)
+			}
+			// 908: ADD #0, #915, rb+0
+			if n.Base() < 0 {
+				return n.Resume(908, 2)
+			}
+			if n.Store(n.Base(), 915) {
+				return n.Resume(912, 1)
+			}
+			// 912: JT #1, #922
+			pc = 922
+			continue
+		case 915:
+			if !n.Enter(3) {
+				return n.Resume(915, 0)
+			}
+			// 915: ADD rb+1, #42931, rb+1
+			if n.Base()+1 < 0 || n.Base()+1 < 0 {
+				return n.Resume(915, 3)
+			}
+			if n.Store(n.Base()+1, n.Load(n.Base()+1)+42931) {
+				return n.Resume(919, 2)
+			}
+			// 919: OUT rb+1
+			if n.Base()+1 < 0 {
+				return n.Resume(919, 2)
+			}
+			if err := n.Output(919, 2, n.Load(n.Base()+1)); err != nil {
+				return err
+			}
+			// 921: HALT
+			return n.Halt(921)
+		case 922:
+			if !n.Enter(3) {
+				return n.Resume(922, 0)
+			}
+			// 922: ARB #3
+			n.Shift(3)
+			// 924: LT rb-2, #3, [63]
+			if n.Base()-2 < 0 {
+				return n.Resume(924, 2)
+			}
+			v924 := int64(0)
+			if n.Load(n.Base()-2) < 3 {
+				v924 = 1
+			}
+			n.Store(63, v924)
+			// 928: JT [63], #964
+			if n.Load(63) != 0 {
+				pc = 964
+				continue
+			}
+			fallthrough
+		case 931:
+			if !n.Enter(3) {
+				return n.Resume(931, 0)
+			}
+			// 931: ADD rb-2, #-1, rb+1
+			if n.Base()-2 < 0 || n.Base()+1 < 0 {
+				return n.Resume(931, 3)
+			}
+			if n.Store(n.Base()+1, n.Load(n.Base()-2)+-1) {
+				return n.Resume(935, 2)
+			}
+			// 935: ADD #942, #0, rb+0
+			if n.Base() < 0 {
+				return n.Resume(935, 2)
+			}
+			if n.Store(n.Base(), 942) {
+				return n.Resume(939, 1)
+			}
+			// 939: JF #0, #922
+			pc = 922
+			continue
+		case 942:
+			if !n.Enter(4) {
+				return n.Resume(942, 0)
+			}
+			// 942: MULT rb+1, #1, rb-1
+			if n.Base()+1 < 0 || n.Base()-1 < 0 {
+				return n.Resume(942, 4)
+			}
+			if n.Store(n.Base()-1, n.Load(n.Base()+1)*1) {
+				return n.Resume(946, 3)
+			}
+			// 946: ADD rb-2, #-3, rb+1
+			if n.Base()-2 < 0 || n.Base()+1 < 0 {
+				return n.Resume(946, 3)
+			}
+			if n.Store(n.Base()+1, n.Load(n.Base()-2)+-3) {
+				return n.Resume(950, 2)
+			}
+			// 950: MULT #1, #957, rb+0
+			if n.Base() < 0 {
+				return n.Resume(950, 2)
+			}
+			if n.Store(n.Base(), 957) {
+				return n.Resume(954, 1)
+			}
+			// 954: JF #0, #922
+			pc = 922
+			continue
+		case 957:
+			if !n.Enter(2) {
+				return n.Resume(957, 0)
+			}
+			// 957: ADD rb+1, rb-1, rb-2
+			if n.Base()+1 < 0 || n.Base()-1 < 0 || n.Base()-2 < 0 {
+				return n.Resume(957, 2)
+			}
+			if n.Store(n.Base()-2, n.Load(n.Base()+1)+n.Load(n.Base()-1)) {
+				return n.Resume(961, 1)
+			}
+			// 961: JF #0, #968
+			pc = 968
+			continue
+		case 964:
+			if !n.Enter(1) {
+				return n.Resume(964, 0)
+			}
+			// 964: ADD #0, rb-2, rb-2
+			if n.Base()-2 < 0 || n.Base()-2 < 0 {
+				return n.Resume(964, 1)
+			}
+			if n.Store(n.Base()-2, 0+n.Load(n.Base()-2)) {
+				return n.Resume(968, 0)
+			}
+			fallthrough
+		case 968:
+			if !n.Enter(2) {
+				return n.Resume(968, 0)
+			}
+			// 968: ARB #-3
+			n.Shift(-3)
+			// 970: JF #0, rb+0
+			if n.Base() < 0 {
+				return n.Resume(970, 1)
+			}
+			target := n.Load(n.Base())
+			if target < 0 {
+				return n.Resume(970, 1)
+			}
+			pc = int(target)
+			continue
+		default:
+			return n.Resume(pc, 0)
+		}
+	}
+}
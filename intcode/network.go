@@ -0,0 +1,330 @@
+package intcode
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// ErrStopNetwork can be returned by a Monitor to stop the network, Run then returns nil
+var ErrStopNetwork = errors.New("stop network")
+
+// idleReads is the number of times in a row a non-blocking node must read no input to be considered idle
+const idleReads = 2
+
+// Packet is a pair of values sent between addressed nodes
+type Packet struct {
+	Src  int
+	Dest int
+	X    int64
+	Y    int64
+}
+
+// Monitor observes a network, like the NAT of day 23. Returning ErrStopNetwork from either method stops the
+// network without error, any other error stops it with that error.
+type Monitor interface {
+	// Packet receives a packet sent to an address with no node
+	Packet(n *Network, p Packet) error
+	// Idle is called whenever every running node is idle, sending input to a node wakes the network
+	Idle(n *Network) error
+}
+
+// MonitorFuncs adapts a pair of functions to a Monitor, either may be nil
+type MonitorFuncs struct {
+	OnPacket func(n *Network, p Packet) error
+	OnIdle   func(n *Network) error
+}
+
+// Packet calls OnPacket if set
+func (m MonitorFuncs) Packet(n *Network, p Packet) error {
+	if m.OnPacket == nil {
+		return nil
+	}
+	return m.OnPacket(n, p)
+}
+
+// Idle calls OnIdle if set
+func (m MonitorFuncs) Idle(n *Network) error {
+	if m.OnIdle == nil {
+		return nil
+	}
+	return m.OnIdle(n)
+}
+
+// Node is a computer in a network
+type Node struct {
+	// Address is the position of the node in the network, used as the destination of packets
+	Address int
+	// Buffer limits how many values can be queued for the node before senders wait, zero means no limit
+	Buffer int
+	// NonBlocking nodes read -1 when no input is queued rather than waiting for it
+	NonBlocking bool
+	// Addressed nodes send their output as packets of (dest, x, y) rather than along their connections
+	Addressed bool
+
+	comp       *Computer
+	targets    []*Node
+	packet     []int64
+	pending    *Packet
+	pendingOut []int64
+	emptyReads int
+}
+
+// Computer gives the computer run by the node
+func (n *Node) Computer() *Computer {
+	return n.comp
+}
+
+// Send queues input for the node, ignoring its buffer limit
+func (n *Node) Send(vals ...int64) {
+	n.comp.SendInput(vals...)
+}
+
+// hasSpace reports if the node can accept a number of values without exceeding its buffer
+func (n *Node) hasSpace(count int) bool {
+	return n.Buffer == 0 || len(n.comp.queued)+count <= n.Buffer
+}
+
+// idle reports if the node has halted or is waiting for input which has not been sent
+func (n *Node) idle() bool {
+	if n.comp.terminated {
+		return true
+	}
+	if n.pending != nil || n.pendingOut != nil || len(n.comp.queued) != 0 {
+		return false
+	}
+	return !n.NonBlocking || n.emptyReads >= idleReads
+}
+
+// Network runs computers whose outputs are routed to the inputs of other computers. An output can be
+// connected to any number of inputs, and any number of outputs to an input. Nodes take turns on the calling
+// goroutine using Resume, so a network needs no channels and always runs the same way.
+type Network struct {
+	// Monitor is told about packets to unknown addresses and when the network goes idle
+	Monitor Monitor
+
+	nodes []*Node
+}
+
+// NewNetwork creates an empty network
+func NewNetwork() *Network {
+	return &Network{}
+}
+
+// AddNode adds a computer to the network, its address is the number of nodes added before it. The computer
+// is driven with Resume and SendInput so should not have an input set.
+func (n *Network) AddNode(c *Computer) *Node {
+	node := &Node{Address: len(n.nodes), comp: c}
+	n.nodes = append(n.nodes, node)
+	return node
+}
+
+// Nodes lists every node in address order
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Node gives the node at an address, nil if there is none
+func (n *Network) Node(addr int) *Node {
+	if addr < 0 || addr >= len(n.nodes) {
+		return nil
+	}
+	return n.nodes[addr]
+}
+
+// Connect sends every output of a node to the input of each of the targets
+func (n *Network) Connect(from *Node, to ...*Node) {
+	from.targets = append(from.targets, to...)
+}
+
+// Send delivers a packet to its destination node ignoring the buffer limit, as a monitor does to wake the
+// network
+func (n *Network) Send(p Packet) error {
+	dest := n.Node(p.Dest)
+	if dest == nil {
+		return errors.Errorf("no node at address %d", p.Dest)
+	}
+	dest.Send(p.X, p.Y)
+	return nil
+}
+
+// Run runs every node until they have all halted or the network is idle. If the network goes idle the
+// Monitor is called, if it does not wake any node a *DeadlockError describing every node is returned.
+func (n *Network) Run() error {
+	return n.RunContext(context.Background())
+}
+
+// RunContext runs the network, checking the context between each round of turns
+func (n *Network) RunContext(ctx context.Context) error {
+	err := n.run(ctx)
+	if err == ErrStopNetwork {
+		return nil
+	}
+	return err
+}
+
+func (n *Network) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		progressed := false
+		for _, node := range n.nodes {
+			nodeProgressed, err := n.turn(node)
+			if err != nil {
+				return err
+			}
+			progressed = progressed || nodeProgressed
+		}
+
+		if n.halted() {
+			return nil
+		}
+		if n.idle() {
+			if n.Monitor == nil {
+				return n.deadlock()
+			}
+			if err := n.Monitor.Idle(n); err != nil {
+				return err
+			}
+			if n.idle() {
+				return n.deadlock()
+			}
+			continue
+		}
+		if !progressed {
+			// every node is waiting to deliver output to a full buffer
+			return n.deadlock()
+		}
+	}
+}
+
+// turn runs a node until it waits for input or cannot deliver its output, reporting if it did anything more
+// than read no input
+func (n *Network) turn(node *Node) (bool, error) {
+	if node.comp.terminated {
+		return false, nil
+	}
+	progressed := len(node.comp.queued) != 0
+	if progressed {
+		node.emptyReads = 0
+	}
+
+	readEmpty := false
+	for !node.comp.terminated {
+		delivered, err := n.deliver(node)
+		if err != nil || !delivered {
+			return progressed, err
+		}
+
+		status, err := node.comp.Resume()
+		if err != nil {
+			return progressed, err
+		}
+		switch status {
+		case Halted:
+			return true, nil
+		case NeedsInput:
+			if !node.NonBlocking || readEmpty {
+				return progressed, nil
+			}
+			node.comp.SendInput(-1)
+			node.emptyReads++
+			readEmpty = true
+		case HasOutput:
+			progressed = true
+			node.emptyReads = 0
+			n.route(node, node.comp.LastOutput())
+		}
+	}
+	return progressed, nil
+}
+
+// route holds an output of the node until it can be delivered, addressed nodes only deliver once a whole
+// packet has been output
+func (n *Network) route(node *Node, val int64) {
+	if !node.Addressed {
+		if len(node.targets) != 0 {
+			node.pendingOut = append(node.pendingOut, val)
+		}
+		return
+	}
+	node.packet = append(node.packet, val)
+	if len(node.packet) == 3 {
+		node.pending = &Packet{Src: node.Address, Dest: int(node.packet[0]), X: node.packet[1], Y: node.packet[2]}
+		node.packet = node.packet[:0]
+	}
+}
+
+// deliver sends any output held by the node, reporting false if a destination has no space for it
+func (n *Network) deliver(node *Node) (bool, error) {
+	if node.pending != nil {
+		p := *node.pending
+		dest := n.Node(p.Dest)
+		switch {
+		case dest == nil && n.Monitor == nil:
+			return false, errors.Errorf("packet from %d to unknown address %d", p.Src, p.Dest)
+		case dest == nil:
+			if err := n.Monitor.Packet(n, p); err != nil {
+				return false, err
+			}
+		case !dest.hasSpace(2):
+			return false, nil
+		default:
+			dest.Send(p.X, p.Y)
+		}
+		node.pending = nil
+	}
+
+	for len(node.pendingOut) != 0 {
+		for _, target := range node.targets {
+			if !target.hasSpace(1) {
+				return false, nil
+			}
+		}
+		for _, target := range node.targets {
+			target.Send(node.pendingOut[0])
+		}
+		node.pendingOut = node.pendingOut[1:]
+	}
+	node.pendingOut = nil
+	return true, nil
+}
+
+// deadlock describes every node of a network which cannot make progress, nodes without a label are named by
+// their address
+func (n *Network) deadlock() error {
+	err := &DeadlockError{}
+	for _, node := range n.nodes {
+		label := node.comp.Label
+		if label == "" {
+			label = strconv.Itoa(node.Address)
+		}
+		err.Nodes = append(err.Nodes, nodeState(label, node.comp))
+	}
+	return err
+}
+
+// halted reports if every node has halted
+func (n *Network) halted() bool {
+	for _, node := range n.nodes {
+		if !node.comp.terminated {
+			return false
+		}
+	}
+	return true
+}
+
+// idle reports if every node is idle
+func (n *Network) idle() bool {
+	for _, node := range n.nodes {
+		if !node.idle() {
+			return false
+		}
+	}
+	return true
+}
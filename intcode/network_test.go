@@ -0,0 +1,165 @@
+package intcode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkFeedbackLoop(t *testing.T) {
+	program := "3,26,1001,26,-4,26,3,27,1002,27,2,27,1,27,26,27,4,27,1001,28,-1,28,1005,28,6,99,0,0,5"
+	n := NewNetwork()
+	var amps []*Node
+	for i, phase := range []int64{9, 8, 7, 6, 5} {
+		amps = append(amps, n.AddNode(newEngineComputer(t, program, false)))
+		amps[i].Buffer = 1
+		amps[i].Send(phase)
+	}
+	for i, amp := range amps {
+		n.Connect(amp, amps[(i+1)%len(amps)])
+	}
+	amps[0].Send(0)
+
+	require.NoError(t, n.Run())
+	assert.Equal(t, int64(139629729), amps[4].Computer().LastOutput())
+}
+
+func TestNetworkFanOutAndFanIn(t *testing.T) {
+	doubler := "3,11,1002,11,2,11,4,11,1105,1,0,0"
+	tripler := "3,11,1002,11,3,11,4,11,1105,1,0,0"
+	echo := "3,7,4,7,1105,1,0,0"
+
+	tt := map[string]struct {
+		buffer    int
+		expOutput []int64
+	}{
+		"unbuffered": {expOutput: []int64{2, 4, 6, 3, 6, 9}},
+		"buffer 1":   {buffer: 1, expOutput: []int64{2, 4, 3, 6, 6, 9}},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			n := NewNetwork()
+			source := n.AddNode(newEngineComputer(t, "104,1,104,2,104,3,99", false))
+			double := n.AddNode(newEngineComputer(t, doubler, false))
+			triple := n.AddNode(newEngineComputer(t, tripler, false))
+			sink := n.AddNode(newEngineComputer(t, echo, false))
+			for _, node := range n.Nodes() {
+				node.Buffer = tc.buffer
+			}
+			n.Connect(source, double, triple)
+			n.Connect(double, sink)
+			n.Connect(triple, sink)
+
+			err := n.Run()
+			assert.True(t, errors.Is(err, ErrDeadlock))
+			assert.True(t, source.Computer().Terminated())
+			assert.Equal(t, tc.expOutput, sink.Computer().Outputs())
+		})
+	}
+}
+
+func TestNetworkFullBuffers(t *testing.T) {
+	n := NewNetwork()
+	source := n.AddNode(newEngineComputer(t, "104,1,104,2,99", false))
+	sink := n.AddNode(newEngineComputer(t, "99", false))
+	sink.Buffer = 1
+	n.Connect(source, sink)
+
+	assert.EqualError(t, n.Run(), "deadlock, every running computer is waiting: [0] waiting at 4, last output 2; [1] halted at 1, no output")
+	assert.False(t, source.Computer().Terminated())
+	assert.Equal(t, []int64{1}, sink.Computer().queued)
+}
+
+// ringNode receives packets, adding one to Y and passing them to the next of three nodes, packets with Y of
+// five or more are sent to address 255
+const ringNode = `
+		IN   [addr]
+loop:	IN   [x]
+		EQ   [x], #-1, [tmp]
+		JT   [tmp], #loop
+		IN   [y]
+		ADD  [y], #1, [y]
+		LT   [y], #5, [tmp]
+		JF   [tmp], #nat
+		ADD  [addr], #1, [dest]
+		EQ   [dest], #3, [tmp]
+		JF   [tmp], #send
+		ADD  #0, #0, [dest]
+send:	OUT  [dest]
+		OUT  [addr]
+		OUT  [y]
+		JT   #1, #loop
+nat:	OUT  #255
+		OUT  [addr]
+		OUT  [y]
+		JT   #1, #loop
+addr:	DB   0
+x:		DB   0
+y:		DB   0
+dest:	DB   0
+tmp:	DB   0`
+
+func TestNetworkPackets(t *testing.T) {
+	program, err := Assemble(ringNode)
+	require.NoError(t, err)
+
+	n := NewNetwork()
+	for i := 0; i < 3; i++ {
+		node := n.AddNode(newEngineComputer(t, program, false))
+		node.NonBlocking = true
+		node.Addressed = true
+		node.Send(int64(i))
+	}
+
+	var natPackets []Packet
+	var last Packet
+	n.Monitor = MonitorFuncs{
+		OnPacket: func(n *Network, p Packet) error {
+			natPackets = append(natPackets, p)
+			last = p
+			return nil
+		},
+		OnIdle: func(n *Network) error {
+			if len(natPackets) == 3 {
+				return ErrStopNetwork
+			}
+			return n.Send(Packet{Dest: 0, X: last.X, Y: last.Y})
+		},
+	}
+	require.NoError(t, n.Send(Packet{Dest: 0}))
+
+	require.NoError(t, n.Run())
+	assert.Equal(t, []Packet{
+		{Src: 1, Dest: 255, X: 1, Y: 5},
+		{Src: 0, Dest: 255, X: 0, Y: 6},
+		{Src: 0, Dest: 255, X: 0, Y: 7},
+	}, natPackets)
+}
+
+func TestNetworkErrors(t *testing.T) {
+	program, err := Assemble(ringNode)
+	require.NoError(t, err)
+
+	n := NewNetwork()
+	node := n.AddNode(newEngineComputer(t, program, false))
+	node.Addressed = true
+	node.Send(0, 0, 10)
+	assert.EqualError(t, n.Run(), "packet from 0 to unknown address 255")
+
+	assert.EqualError(t, n.Send(Packet{Dest: 1}), "no node at address 1")
+
+	n = NewNetwork()
+	n.AddNode(newEngineComputer(t, "1,-1,0,0,99", false))
+	var execErr *ExecutionError
+	assert.True(t, errors.As(n.Run(), &execErr))
+
+	n = NewNetwork()
+	n.AddNode(newEngineComputer(t, "3,0,99", false))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, n.RunContext(ctx))
+}
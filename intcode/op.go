@@ -0,0 +1,324 @@
+package intcode
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+var Reset = "\033[0m"
+var Red = "\033[31m"
+var Green = "\033[32m"
+var Yellow = "\033[33m"
+var Blue = "\033[34m"
+var Purple = "\033[35m"
+var Cyan = "\033[36m"
+
+type OpCode int
+
+const (
+	OpCodeAdd           OpCode = 1
+	OpCodeMultiply      OpCode = 2
+	OpCodeInput         OpCode = 3
+	OpCodeOutput        OpCode = 4
+	OpCodeJumpIfTrue    OpCode = 5
+	OpCodeJumpIfFalse   OpCode = 6
+	OpCodeLessThan      OpCode = 7
+	OpCodeEqual         OpCode = 8
+	OpCodeShiftRelative OpCode = 9
+	OpCodeHalt          OpCode = 99
+)
+
+// String gives the assembler mnemonic for the op code
+func (o OpCode) String() string {
+	for mnemonic, spec := range opSpecs {
+		if spec.code == o {
+			return mnemonic
+		}
+	}
+	return strconv.Itoa(int(o))
+}
+
+type instruction interface {
+	Apply(c *Computer) error
+}
+
+func extractModeData(opcode int64) []int {
+	modeData := opcode / 100
+	var modes []int
+	digits := fmt.Sprintf("%d", modeData)
+	for i := len(digits) - 1; i >= 0; i-- {
+		mode, _ := strconv.Atoi(string(digits[i]))
+		modes = append(modes, mode)
+	}
+	return modes
+}
+
+func readOp(c *Computer) (instruction, error) {
+	opPtr := c.insPtr
+	opValue, err := c.read()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read op code")
+	}
+
+	code := opValue % 100
+	modes := extractModeData(opValue)
+
+	switch OpCode(code) {
+	case OpCodeAdd:
+		return newAddOp(c, modes)
+	case OpCodeMultiply:
+		return newMultiplyOp(c, modes)
+	case OpCodeInput:
+		return newInputOp(c, modes)
+	case OpCodeOutput:
+		return newOutputOp(c, modes)
+	case OpCodeJumpIfTrue:
+		return newJumpOp(c, modes, true)
+	case OpCodeJumpIfFalse:
+		return newJumpOp(c, modes, false)
+	case OpCodeLessThan:
+		return newLessThanOp(c, modes)
+	case OpCodeEqual:
+		return newEqualOp(c, modes)
+	case OpCodeShiftRelative:
+		return newShiftRelativeOp(c, modes)
+	case OpCodeHalt:
+		return newHaltOp(c)
+	default:
+		return nil, &UnknownOpCodeError{OpCode: opValue, InsPtr: opPtr}
+	}
+}
+
+// ---- OPERATIONS ----
+
+type param struct {
+	val  int64
+	mode int
+}
+
+type basicOp struct {
+	params []param
+}
+
+func newBasicOp(c *Computer, paramSize int, inputModes []int) (basicOp, error) {
+	for m := paramSize; m < len(inputModes); m++ {
+		if inputModes[m] != PostionMode {
+			return basicOp{}, &InvalidModeError{Mode: inputModes[m], Param: m}
+		}
+	}
+	completeModes := make([]int, paramSize)
+	for m := 0; m < paramSize && m < len(inputModes); m++ {
+		completeModes[m] = inputModes[m]
+	}
+
+	params := make([]param, paramSize)
+	for i := 0; i < paramSize; i++ {
+		p, err := c.read()
+		if err != nil {
+			return basicOp{}, errors.Wrapf(err, "unable to read param %d", i)
+		}
+		// Addresses are checked when the param is accessed, as the relative base may change before then
+		if completeModes[i] != PostionMode && completeModes[i] != AbsoluteMode && completeModes[i] != RelativeMode {
+			return basicOp{}, &InvalidModeError{Mode: completeModes[i], Param: i}
+		}
+		params[i] = param{val: p, mode: completeModes[i]}
+	}
+	return basicOp{params: params}, nil
+}
+
+// ---- binary op ----
+
+type binaryOp struct {
+	basicOp
+	code        OpCode
+	operator    func(x, y int64) int64
+	bigOperator func(x, y *big.Int) *big.Int
+	logFormat   string
+}
+
+func newBinaryOp(code OpCode, logFormat string, c *Computer, modes []int, operator func(x, y int64) int64, bigOperator func(x, y *big.Int) *big.Int) (binaryOp, error) {
+	op, err := newBasicOp(c, 3, modes)
+	return binaryOp{basicOp: op, code: code, operator: operator, bigOperator: bigOperator, logFormat: logFormat}, err
+}
+
+func (b binaryOp) Apply(c *Computer) error {
+	arg1, big1, err := c.readBigMode(b.params[0])
+	if err != nil {
+		return err
+	}
+	arg2, big2, err := c.readBigMode(b.params[1])
+	if err != nil {
+		return err
+	}
+	if big1 != nil || big2 != nil || (c.Arithmetic != WrapArithmetic && overflows(b.code, arg1, arg2)) {
+		return b.applyBig(c, bigOperand(arg1, big1), bigOperand(arg2, big2))
+	}
+	result := b.operator(arg1, arg2)
+	if err := c.storeAtAddr(b.params[2], result); err != nil {
+		return err
+	}
+	c.logf(b.logFormat+"\n", arg1, arg2, result)
+	return nil
+}
+
+// ---- Add Op ----
+
+type addOp struct{ binaryOp }
+
+func add(x, y int64) int64 { return x + y }
+
+func newAddOp(c *Computer, modes []int) (addOp, error) {
+	op, err := newBinaryOp(OpCodeAdd, "ADD : %d + %d = %d", c, modes, add, bigAdd)
+	return addOp{binaryOp: op}, err
+}
+
+// ---- Multiply Op ----
+
+type multiplyOp struct{ binaryOp }
+
+func multiply(x, y int64) int64 { return x * y }
+
+func newMultiplyOp(c *Computer, modes []int) (multiplyOp, error) {
+	op, err := newBinaryOp(OpCodeMultiply, "MULT: %d * %d = %d", c, modes, multiply, bigMultiply)
+	return multiplyOp{binaryOp: op}, err
+}
+
+// ---- Halt Op ----
+
+type haltOp struct{ basicOp }
+
+func newHaltOp(c *Computer) (haltOp, error) {
+	op, err := newBasicOp(c, 0, nil)
+	return haltOp{basicOp: op}, err
+}
+
+func (h haltOp) Apply(c *Computer) error {
+	c.terminated = true
+	c.logOutf("%sHALT%s\n", Red, Reset)
+	return nil
+}
+
+// ---- Input Op ----
+
+type inputOp struct{ basicOp }
+
+func newInputOp(c *Computer, modes []int) (inputOp, error) {
+	op, err := newBasicOp(c, 1, modes)
+	return inputOp{basicOp: op}, err
+}
+
+func (i inputOp) Apply(c *Computer) error {
+	input, err := c.readInput()
+	if err != nil {
+		return err
+	}
+	return c.storeAtAddr(i.params[0], input)
+}
+
+// ---- Output Op ----
+
+type outputOp struct{ basicOp }
+
+func newOutputOp(c *Computer, modes []int) (outputOp, error) {
+	op, err := newBasicOp(c, 1, modes)
+	return outputOp{basicOp: op}, err
+}
+
+func (o outputOp) Apply(c *Computer) error {
+	out, exact, err := c.readBigMode(o.params[0])
+	if err != nil {
+		return err
+	}
+	if exact != nil {
+		return c.writeBigOutput(exact)
+	}
+	return c.writeOutput(out)
+}
+
+// ---- Jump Op ----
+
+type jumpOp struct {
+	basicOp
+	jumpWhen bool
+}
+
+func newJumpOp(c *Computer, modes []int, jumpWhen bool) (jumpOp, error) {
+	op, err := newBasicOp(c, 2, modes)
+	return jumpOp{basicOp: op, jumpWhen: jumpWhen}, err
+}
+
+func (j jumpOp) Apply(c *Computer) error {
+	arg, err := c.readMode(j.params[0])
+	if err != nil {
+		return err
+	}
+	argIsNonZero := arg != 0
+	if argIsNonZero == j.jumpWhen {
+		target, err := c.readMode(j.params[1])
+		if err != nil {
+			return err
+		}
+		if c.addrOutOfBounds(int(target)) {
+			return errors.Wrap(&OutOfBoundsError{Addr: int(target)}, "invalid jump target")
+		}
+		c.insPtr = int(target)
+		c.logf("JUMP: to %d (arg %d)\n", c.insPtr, arg)
+		return nil
+	}
+	c.logf("CONT: (arg %d)\n", arg)
+	return nil
+}
+
+// ---- Less than Op ----
+
+type lessThanOp struct{ binaryOp }
+
+func lessThan(x, y int64) int64 {
+	if x < y {
+		return 1
+	}
+	return 0
+}
+
+func newLessThanOp(c *Computer, modes []int) (lessThanOp, error) {
+	op, err := newBinaryOp(OpCodeLessThan, "LESS: %d < %d (%d)", c, modes, lessThan, bigLessThan)
+	return lessThanOp{binaryOp: op}, err
+}
+
+// ---- Greater than Op ----
+
+type equalOp struct{ binaryOp }
+
+func equal(x, y int64) int64 {
+	if x == y {
+		return 1
+	}
+	return 0
+}
+
+func newEqualOp(c *Computer, modes []int) (equalOp, error) {
+	op, err := newBinaryOp(OpCodeEqual, "EQ  : %d == %d (%d)", c, modes, equal, bigEqual)
+	return equalOp{binaryOp: op}, err
+}
+
+// ---- Shift Relative Op ----
+
+type shiftRelativeOp struct{ basicOp }
+
+func newShiftRelativeOp(c *Computer, modes []int) (shiftRelativeOp, error) {
+	op, err := newBasicOp(c, 1, modes)
+	return shiftRelativeOp{basicOp: op}, err
+}
+
+func (s shiftRelativeOp) Apply(c *Computer) error {
+	shift, err := c.readMode(s.params[0])
+	if err != nil {
+		return err
+	}
+	c.logf("REL : %d->%d\n", c.relativeBase, c.relativeBase+int(shift))
+	c.relativeBase += int(shift)
+	return nil
+}
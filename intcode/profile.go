@@ -0,0 +1,277 @@
+package intcode
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// allOpCodes lists every op code in numeric order
+var allOpCodes = []OpCode{
+	OpCodeAdd, OpCodeMultiply, OpCodeInput, OpCodeOutput, OpCodeJumpIfTrue, OpCodeJumpIfFalse,
+	OpCodeLessThan, OpCodeEqual, OpCodeShiftRelative, OpCodeHalt,
+}
+
+// Profiler counts how often each instruction, op code and memory cell is used while a computer runs. Set
+// it as the Profiler of a computer before running, the fast engine is not used while profiling.
+type Profiler struct {
+	// Executions counts executions by instruction address
+	Executions map[int]int
+	// OpCodes counts executions by op code
+	OpCodes map[OpCode]int
+	// Reads counts reads by position and relative mode params by address
+	Reads map[int]int
+	// Writes counts writes by instructions by address
+	Writes map[int]int
+	// Loops counts how often each backward jump was taken
+	Loops map[Loop]int
+
+	covered     map[int]bool
+	programSize int
+}
+
+// Loop is the range of addresses between the target of a backward jump and the jump itself
+type Loop struct {
+	Start int
+	End   int
+}
+
+// AddrCount is a count for an address
+type AddrCount struct {
+	Addr  int
+	Count int
+}
+
+// LoopCount is the number of times a loop was taken and the instructions executed within its range
+type LoopCount struct {
+	Loop
+	Iterations   int
+	Instructions int
+}
+
+// CellCount is the number of reads and writes of a memory cell
+type CellCount struct {
+	Addr   int
+	Reads  int
+	Writes int
+}
+
+// AddrRange is a range of addresses from Start to End inclusive
+type AddrRange struct {
+	Start int
+	End   int
+}
+
+func (r AddrRange) String() string {
+	if r.Start == r.End {
+		return fmt.Sprintf("%d", r.Start)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// NewProfiler creates an empty profiler
+func NewProfiler() *Profiler {
+	return &Profiler{
+		Executions: make(map[int]int),
+		OpCodes:    make(map[OpCode]int),
+		Reads:      make(map[int]int),
+		Writes:     make(map[int]int),
+		Loops:      make(map[Loop]int),
+		covered:    make(map[int]bool),
+	}
+}
+
+// begin records the size of the program the first time an instruction is about to execute
+func (p *Profiler) begin(c *Computer) {
+	if p.programSize == 0 {
+		p.programSize = c.memory.len()
+	}
+}
+
+// recordInstruction counts an executed instruction, the instruction pointer of the computer has moved on
+// to the next instruction
+func (p *Profiler) recordInstruction(c *Computer, addr int, size int, code OpCode) {
+	p.Executions[addr]++
+	p.OpCodes[code]++
+	for i := 0; i < size; i++ {
+		p.covered[addr+i] = true
+	}
+	if (code == OpCodeJumpIfTrue || code == OpCodeJumpIfFalse) && c.insPtr <= addr {
+		p.Loops[Loop{Start: c.insPtr, End: addr}]++
+	}
+}
+
+// profileRead counts a memory read made by the instruction being executed
+func (c *Computer) profileRead(addr int) {
+	if c.profiling {
+		c.Profiler.Reads[addr]++
+	}
+}
+
+// profileWrite counts a memory write made by the instruction being executed
+func (c *Computer) profileWrite(addr int) {
+	if c.profiling {
+		c.Profiler.Writes[addr]++
+	}
+}
+
+// Total is the number of instructions executed
+func (p *Profiler) Total() int {
+	total := 0
+	for _, count := range p.OpCodes {
+		total += count
+	}
+	return total
+}
+
+// HotSpots lists the n most executed instruction addresses, all of them if n is zero
+func (p *Profiler) HotSpots(n int) []AddrCount {
+	var result []AddrCount
+	for addr, count := range p.Executions {
+		result = append(result, AddrCount{Addr: addr, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Addr < result[j].Addr
+	})
+	return result[:limitLen(len(result), n)]
+}
+
+// HotLoops lists the n loops which executed the most instructions, all of them if n is zero
+func (p *Profiler) HotLoops(n int) []LoopCount {
+	var result []LoopCount
+	for loop, iterations := range p.Loops {
+		lc := LoopCount{Loop: loop, Iterations: iterations}
+		for addr := loop.Start; addr <= loop.End; addr++ {
+			lc.Instructions += p.Executions[addr]
+		}
+		result = append(result, lc)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Instructions != result[j].Instructions {
+			return result[i].Instructions > result[j].Instructions
+		}
+		return result[i].Start < result[j].Start
+	})
+	return result[:limitLen(len(result), n)]
+}
+
+// HotCells lists the n memory cells with the most reads and writes, all of them if n is zero
+func (p *Profiler) HotCells(n int) []CellCount {
+	cells := make(map[int]*CellCount)
+	cell := func(addr int) *CellCount {
+		if _, ok := cells[addr]; !ok {
+			cells[addr] = &CellCount{Addr: addr}
+		}
+		return cells[addr]
+	}
+	for addr, count := range p.Reads {
+		cell(addr).Reads = count
+	}
+	for addr, count := range p.Writes {
+		cell(addr).Writes = count
+	}
+
+	var result []CellCount
+	for _, c := range cells {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Reads+result[i].Writes != result[j].Reads+result[j].Writes {
+			return result[i].Reads+result[i].Writes > result[j].Reads+result[j].Writes
+		}
+		return result[i].Addr < result[j].Addr
+	})
+	return result[:limitLen(len(result), n)]
+}
+
+// NeverExecuted lists the ranges of the program, as loaded when profiling started, which were not part of
+// any executed instruction. Data as well as unused code is included.
+func (p *Profiler) NeverExecuted() []AddrRange {
+	var result []AddrRange
+	for addr := 0; addr < p.programSize; addr++ {
+		if p.covered[addr] {
+			continue
+		}
+		if len(result) != 0 && result[len(result)-1].End == addr-1 {
+			result[len(result)-1].End = addr
+			continue
+		}
+		result = append(result, AddrRange{Start: addr, End: addr})
+	}
+	return result
+}
+
+// MissingOpCodes lists the op codes which were never executed
+func (p *Profiler) MissingOpCodes() []OpCode {
+	var result []OpCode
+	for _, code := range allOpCodes {
+		if p.OpCodes[code] == 0 {
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+// WriteReport writes a summary of the profile, listing the top n entries of each section
+func (p *Profiler) WriteReport(w io.Writer, n int) error {
+	var sb strings.Builder
+	total := p.Total()
+	fmt.Fprintf(&sb, "instructions executed: %d\n", total)
+
+	sb.WriteString("\nop codes:\n")
+	for _, code := range allOpCodes {
+		count := p.OpCodes[code]
+		if count == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "  %-4s %12d %6.2f%%\n", code, count, percent(count, total))
+	}
+	if missing := p.MissingOpCodes(); len(missing) != 0 {
+		fmt.Fprintf(&sb, "  never executed: %v\n", missing)
+	}
+
+	sb.WriteString("\nhot spots:\n")
+	for _, hs := range p.HotSpots(n) {
+		fmt.Fprintf(&sb, "  %6d %12d %6.2f%%\n", hs.Addr, hs.Count, percent(hs.Count, total))
+	}
+
+	sb.WriteString("\nhot loops:\n")
+	for _, l := range p.HotLoops(n) {
+		fmt.Fprintf(&sb, "  %6d-%-6d %10d iterations %12d instructions %6.2f%%\n",
+			l.Start, l.End, l.Iterations, l.Instructions, percent(l.Instructions, total))
+	}
+
+	sb.WriteString("\nhot memory:\n")
+	for _, c := range p.HotCells(n) {
+		fmt.Fprintf(&sb, "  %6d %10d reads %10d writes\n", c.Addr, c.Reads, c.Writes)
+	}
+
+	sb.WriteString("\nnever executed:\n")
+	var ranges []string
+	for _, r := range p.NeverExecuted() {
+		ranges = append(ranges, r.String())
+	}
+	fmt.Fprintf(&sb, "  %s\n", strings.Join(ranges, ", "))
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func percent(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(count) / float64(total)
+}
+
+// limitLen gives how many of the entries to keep when limited to n, all of them if n is zero
+func limitLen(length int, n int) int {
+	if n > 0 && length > n {
+		return n
+	}
+	return length
+}
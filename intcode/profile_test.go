@@ -0,0 +1,61 @@
+package intcode
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Counts [20] down from 3 in a loop, followed by two data values
+const profileProgram = "1101,3,0,20,1001,20,-1,20,1005,20,4,99,7,7"
+
+func runProfiled(t *testing.T, program string) *Profiler {
+	testComp := newEngineComputer(t, program, true)
+	testComp.Profiler = NewProfiler()
+	require.NoError(t, testComp.Run())
+	return testComp.Profiler
+}
+
+func TestProfiler(t *testing.T) {
+	p := runProfiled(t, profileProgram)
+
+	assert.Equal(t, 8, p.Total())
+	assert.Equal(t, map[int]int{0: 1, 4: 3, 8: 3, 11: 1}, p.Executions)
+	assert.Equal(t, map[OpCode]int{OpCodeAdd: 4, OpCodeJumpIfTrue: 3, OpCodeHalt: 1}, p.OpCodes)
+	assert.Equal(t, []AddrCount{{Addr: 4, Count: 3}, {Addr: 8, Count: 3}}, p.HotSpots(2))
+	assert.Equal(t, []LoopCount{{Loop: Loop{Start: 4, End: 8}, Iterations: 2, Instructions: 6}}, p.HotLoops(0))
+	assert.Equal(t, []CellCount{{Addr: 20, Reads: 6, Writes: 4}}, p.HotCells(0))
+	assert.Equal(t, []AddrRange{{Start: 12, End: 13}}, p.NeverExecuted())
+	assert.Equal(t, []OpCode{
+		OpCodeMultiply, OpCodeInput, OpCodeOutput, OpCodeJumpIfFalse, OpCodeLessThan, OpCodeEqual, OpCodeShiftRelative,
+	}, p.MissingOpCodes())
+}
+
+func TestProfilerReport(t *testing.T) {
+	p := runProfiled(t, profileProgram)
+
+	var buf bytes.Buffer
+	require.NoError(t, p.WriteReport(&buf, 1))
+	assert.Equal(t, `instructions executed: 8
+
+op codes:
+  ADD             4  50.00%
+  JT              3  37.50%
+  HALT            1  12.50%
+  never executed: [MULT IN OUT JF LT EQ ARB]
+
+hot spots:
+       4            3  37.50%
+
+hot loops:
+       4-8               2 iterations            6 instructions  75.00%
+
+hot memory:
+      20          6 reads          4 writes
+
+never executed:
+  12-13
+`, buf.String())
+}
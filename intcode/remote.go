@@ -0,0 +1,274 @@
+package intcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MessageKind is the type of a message sent by a Server
+type MessageKind string
+
+const (
+	// MessageOutput carries a value output by the program, or a numeric result in ASCII mode
+	MessageOutput MessageKind = "OUT"
+	// MessageLine carries a line of text output by the program in ASCII mode
+	MessageLine MessageKind = "LINE"
+	// MessageInput means the program is waiting for input, in ASCII mode the text is any prompt
+	MessageInput MessageKind = "INPUT"
+	// MessageInvalidInput rejects an input line which is not an integer, the program keeps waiting for input
+	MessageInvalidInput MessageKind = "INVALID"
+	// MessageHalt means the program has finished
+	MessageHalt MessageKind = "HALT"
+	// MessageError carries the error the program stopped with
+	MessageError MessageKind = "ERROR"
+)
+
+// Message is a line sent by a Server, the kind followed by a value or text
+type Message struct {
+	Kind  MessageKind
+	Value int64
+	Text  string
+}
+
+func (m Message) String() string {
+	switch {
+	case m.Kind == MessageOutput:
+		return fmt.Sprintf("%s %d", m.Kind, m.Value)
+	case m.Text != "" || m.Kind == MessageLine:
+		return fmt.Sprintf("%s %s", m.Kind, m.Text)
+	}
+	return string(m.Kind)
+}
+
+// parseMessage reads a message from a line sent by a server
+func parseMessage(line string) (Message, error) {
+	parts := strings.SplitN(line, " ", 2)
+	m := Message{Kind: MessageKind(parts[0])}
+	if len(parts) == 2 {
+		m.Text = parts[1]
+	}
+	switch m.Kind {
+	case MessageOutput:
+		val, err := strconv.ParseInt(m.Text, 10, 64)
+		if err != nil {
+			return Message{}, errors.Wrapf(err, "invalid output message %q", line)
+		}
+		m.Value, m.Text = val, ""
+	case MessageLine, MessageInput, MessageInvalidInput, MessageHalt, MessageError:
+	default:
+		return Message{}, errors.Errorf("unknown message %q", line)
+	}
+	return m, nil
+}
+
+// RemoteError is an error reported by a Server
+type RemoteError struct {
+	Msg string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("remote computer: %s", e.Msg)
+}
+
+// InvalidInputError is returned by Client.Resume when the server rejected an input line, the program is
+// still waiting for input so another can be sent
+type InvalidInputError struct {
+	Input string
+}
+
+func (e *InvalidInputError) Error() string {
+	return fmt.Sprintf("invalid input %q", e.Input)
+}
+
+// Server hosts a computer so that another process can drive it over a connection with a line protocol.
+// The client sends one integer per line to be read as input, or in ASCII mode one line of text. The server
+// sends a Message per line: each output, then INPUT when the program waits for input or HALT once it has
+// finished. A line which is not an integer is answered with INVALID and the program keeps waiting. The
+// computer is run synchronously with Resume and keeps its state between connections.
+type Server struct {
+	c     *Computer
+	ascii *ASCII
+}
+
+// NewServer creates a server for the computer, which exchanges single values with clients
+func NewServer(c *Computer) *Server {
+	return &Server{c: c}
+}
+
+// NewASCIIServer creates a server for the computer which exchanges lines of text with clients, see ASCII
+func NewASCIIServer(c *Computer) *Server {
+	return &Server{c: c, ascii: NewASCII(c)}
+}
+
+// Serve accepts connections from the listener one at a time until the program halts or stops with an error.
+// A client disconnecting leaves the program waiting for the next one.
+func (s *Server) Serve(l net.Listener) error {
+	for !s.c.Terminated() {
+		conn, err := l.Accept()
+		if err != nil {
+			return errors.Wrap(err, "unable to accept connection")
+		}
+		err = s.ServeConn(conn)
+		conn.Close()
+		var remote *RemoteError
+		if errors.As(err, &remote) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeConn runs the program with a single client until the program halts or the client disconnects.
+// Errors from the program are sent to the client and returned as a *RemoteError.
+func (s *Server) ServeConn(conn io.ReadWriter) error {
+	w := bufio.NewWriter(conn)
+	scanner := bufio.NewScanner(conn)
+	for {
+		status, err := s.resume(w)
+		if err != nil {
+			s.send(w, Message{Kind: MessageError, Text: err.Error()})
+			w.Flush()
+			return &RemoteError{Msg: err.Error()}
+		}
+		if status == Halted {
+			s.send(w, Message{Kind: MessageHalt})
+			return errors.Wrap(w.Flush(), "unable to send message")
+		}
+
+		input := Message{Kind: MessageInput}
+		if s.ascii != nil {
+			input.Text = s.ascii.Prompt()
+		}
+		s.send(w, input)
+		if err := w.Flush(); err != nil {
+			return errors.Wrap(err, "unable to send message")
+		}
+		if ok, err := s.readInput(w, scanner); !ok {
+			return err
+		}
+	}
+}
+
+// readInput reads lines until one is valid input and sends it to the program, reporting false if the client
+// has disconnected
+func (s *Server) readInput(w *bufio.Writer, scanner *bufio.Scanner) (bool, error) {
+	for scanner.Scan() {
+		if s.ascii != nil {
+			s.ascii.SendLine(strings.TrimRight(scanner.Text(), "\r"))
+			return true, nil
+		}
+		val, err := strconv.ParseInt(strings.TrimSpace(scanner.Text()), 10, 64)
+		if err == nil {
+			s.c.SendInput(val)
+			return true, nil
+		}
+		s.send(w, Message{Kind: MessageInvalidInput, Text: scanner.Text()})
+		if err := w.Flush(); err != nil {
+			return false, errors.Wrap(err, "unable to send message")
+		}
+	}
+	return false, errors.Wrap(scanner.Err(), "unable to read input")
+}
+
+// resume runs the program until it halts or needs input, sending each output as it is produced
+func (s *Server) resume(w *bufio.Writer) (Status, error) {
+	for {
+		status, err := s.c.Resume()
+		if err != nil || status != HasOutput {
+			return status, err
+		}
+		val := s.c.LastOutput()
+		if s.ascii == nil || val < 0 || val > maxASCII {
+			s.send(w, Message{Kind: MessageOutput, Value: val})
+			continue
+		}
+		s.ascii.receive(val)
+		for _, line := range s.ascii.ReadLines() {
+			s.send(w, Message{Kind: MessageLine, Text: line})
+		}
+	}
+}
+
+// send writes a message, errors are left for the writer to report when flushed
+func (s *Server) send(w *bufio.Writer, m Message) {
+	w.WriteString(m.String())
+	w.WriteByte('\n')
+}
+
+// Client drives a computer hosted by a Server
+type Client struct {
+	conn    io.ReadWriteCloser
+	scanner *bufio.Scanner
+}
+
+// Dial connects to a server, the network is "tcp" or "unix"
+func Dial(network, addr string) (*Client, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect to server")
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient creates a client talking to a server over the connection
+func NewClient(conn io.ReadWriteCloser) *Client {
+	return &Client{conn: conn, scanner: bufio.NewScanner(conn)}
+}
+
+// Next reads the next message from the server, io.EOF is returned once the server has closed the connection
+func (c *Client) Next() (Message, error) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return Message{}, errors.Wrap(err, "unable to read message")
+		}
+		return Message{}, io.EOF
+	}
+	return parseMessage(c.scanner.Text())
+}
+
+// Resume reads messages until the program waits for input or halts, giving the status and the output and
+// line messages received. An error message from the server is returned as a *RemoteError with the status
+// Failed, as is any error reading from the connection. A rejected input line gives NeedsInput with an
+// *InvalidInputError, the program is still waiting so another can be sent.
+func (c *Client) Resume() (Status, []Message, error) {
+	var messages []Message
+	for {
+		m, err := c.Next()
+		if err != nil {
+			return Failed, messages, err
+		}
+		switch m.Kind {
+		case MessageInput:
+			return NeedsInput, messages, nil
+		case MessageInvalidInput:
+			return NeedsInput, messages, &InvalidInputError{Input: m.Text}
+		case MessageHalt:
+			return Halted, messages, nil
+		case MessageError:
+			return Failed, messages, &RemoteError{Msg: m.Text}
+		}
+		messages = append(messages, m)
+	}
+}
+
+// Send sends a value to be read as input
+func (c *Client) Send(val int64) error {
+	return c.SendLine(strconv.FormatInt(val, 10))
+}
+
+// SendLine sends a line of text, to be read as input by a server in ASCII mode
+func (c *Client) SendLine(line string) error {
+	_, err := io.WriteString(c.conn, line+"\n")
+	return errors.Wrap(err, "unable to send input")
+}
+
+// Close closes the connection, leaving the program waiting for another client
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
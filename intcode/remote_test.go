@@ -0,0 +1,164 @@
+package intcode
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sumProgram reads two values and outputs their sum and product
+const sumProgram = "3,17,3,18,1,17,18,19,4,19,2,17,18,19,4,19,99"
+
+// serveTestConn serves the computer over one end of a pipe, giving a client for the other end and a channel
+// receiving the result of ServeConn
+func serveTestConn(t *testing.T, server *Server) (*Client, <-chan error) {
+	serverConn, clientConn := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- server.ServeConn(serverConn)
+		serverConn.Close()
+	}()
+	client := NewClient(clientConn)
+	t.Cleanup(func() { client.Close() })
+	return client, done
+}
+
+func TestServer(t *testing.T) {
+	client, done := serveTestConn(t, NewServer(newEngineComputer(t, sumProgram, false)))
+
+	status, messages, err := client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, NeedsInput, status)
+	assert.Empty(t, messages)
+
+	require.NoError(t, client.Send(3))
+	_, _, err = client.Resume()
+	require.NoError(t, err)
+	require.NoError(t, client.SendLine("four"))
+	status, _, err = client.Resume()
+	var invalid *InvalidInputError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, "four", invalid.Input)
+	assert.Equal(t, NeedsInput, status)
+
+	require.NoError(t, client.Send(4))
+	status, messages, err = client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, Halted, status)
+	assert.Equal(t, []Message{{Kind: MessageOutput, Value: 7}, {Kind: MessageOutput, Value: 12}}, messages)
+	assert.NoError(t, <-done)
+}
+
+func TestServerInvalidInput(t *testing.T) {
+	client, done := serveTestConn(t, NewServer(newEngineComputer(t, "3,0,4,0,99", false)))
+
+	status, _, err := client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, NeedsInput, status)
+	for _, line := range []string{"abc", ""} {
+		require.NoError(t, client.SendLine(line))
+		status, _, err = client.Resume()
+		assert.EqualError(t, err, fmt.Sprintf("invalid input %q", line))
+		assert.Equal(t, NeedsInput, status)
+	}
+
+	require.NoError(t, client.Send(5))
+	status, messages, err := client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, Halted, status)
+	assert.Equal(t, []Message{{Kind: MessageOutput, Value: 5}}, messages)
+	assert.NoError(t, <-done)
+}
+
+func TestASCIIServer(t *testing.T) {
+	client, done := serveTestConn(t, NewASCIIServer(newEngineComputer(t, promptProgram, false)))
+
+	status, messages, err := client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, NeedsInput, status)
+	assert.Equal(t, []Message{{Kind: MessageLine, Text: "OK"}, {Kind: MessageOutput, Value: 1000}}, messages)
+
+	require.NoError(t, client.SendLine("42"))
+	status, messages, err = client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, Halted, status)
+	assert.Equal(t, []Message{{Kind: MessageLine, Text: ">42"}}, messages)
+	assert.NoError(t, <-done)
+}
+
+func TestServerProgramError(t *testing.T) {
+	client, done := serveTestConn(t, NewServer(newEngineComputer(t, "3,0,42", false)))
+
+	_, _, err := client.Resume()
+	require.NoError(t, err)
+	require.NoError(t, client.Send(1))
+	_, _, err = client.Resume()
+	var remote *RemoteError
+	require.ErrorAs(t, err, &remote)
+	assert.Contains(t, remote.Msg, "unrecognized op code 42")
+	assert.ErrorAs(t, <-done, &remote)
+}
+
+func TestServeReconnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	done := make(chan error, 1)
+	go func() { done <- NewServer(newEngineComputer(t, sumProgram, false)).Serve(l) }()
+
+	// the first client leaves the program waiting for its second value
+	client, err := Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	_, _, err = client.Resume()
+	require.NoError(t, err)
+	require.NoError(t, client.Send(5))
+	_, _, err = client.Resume()
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	client, err = Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	status, _, err := client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, NeedsInput, status)
+	require.NoError(t, client.Send(6))
+	status, messages, err := client.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, Halted, status)
+	assert.Equal(t, []Message{{Kind: MessageOutput, Value: 11}, {Kind: MessageOutput, Value: 30}}, messages)
+	assert.NoError(t, <-done)
+}
+
+func TestParseMessage(t *testing.T) {
+	tt := map[string]struct {
+		line    string
+		message Message
+		err     string
+	}{
+		"output":       {line: "OUT -5", message: Message{Kind: MessageOutput, Value: -5}},
+		"line":         {line: "LINE hello world", message: Message{Kind: MessageLine, Text: "hello world"}},
+		"empty line":   {line: "LINE ", message: Message{Kind: MessageLine}},
+		"prompt":       {line: "INPUT Command?", message: Message{Kind: MessageInput, Text: "Command?"}},
+		"halt":         {line: "HALT", message: Message{Kind: MessageHalt}},
+		"invalid":      {line: "INVALID abc", message: Message{Kind: MessageInvalidInput, Text: "abc"}},
+		"bad output":   {line: "OUT x", err: `invalid output message "OUT x": strconv.ParseInt: parsing "x": invalid syntax`},
+		"unknown kind": {line: "HELLO", err: `unknown message "HELLO"`},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			m, err := parseMessage(tc.line)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.message, m)
+			assert.Equal(t, tc.line, m.String())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package intcode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstructionBudget(t *testing.T) {
+	tt := map[string]struct {
+		inputCode       string
+		maxInstructions int
+		expErr          error
+		expInsPtr       int
+	}{
+		"infinite loop":    {inputCode: "1105,1,0", maxInstructions: 100, expErr: ErrInstructionBudget, expInsPtr: 0},
+		"runs out midway":  {inputCode: "1101,1,1,0,1101,1,1,0,99", maxInstructions: 1, expErr: ErrInstructionBudget, expInsPtr: 4},
+		"within budget":    {inputCode: "1101,1,1,0,1101,1,1,0,99", maxInstructions: 3},
+		"unlimited budget": {inputCode: "1101,1,1,0,1101,1,1,0,99"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			testComp, err := NewComputer(tc.inputCode, nil)
+			require.NoError(t, err)
+			testComp.DisableLog = true
+			testComp.MaxInstructions = tc.maxInstructions
+
+			err = testComp.Run()
+			if tc.expErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expErr)
+			var execErr *ExecutionError
+			require.ErrorAs(t, err, &execErr)
+			assert.Equal(t, tc.expInsPtr, execErr.InsPtr)
+		})
+	}
+}
+
+func TestRunContextCancellation(t *testing.T) {
+	tt := map[string]struct {
+		inputCode string
+		timeout   time.Duration
+		expErr    error
+	}{
+		"infinite loop":        {inputCode: "1105,1,0", timeout: 10 * time.Millisecond, expErr: context.DeadlineExceeded},
+		"blocked on input":     {inputCode: "3,0,99", timeout: 10 * time.Millisecond, expErr: context.DeadlineExceeded},
+		"blocked on output":    {inputCode: "104,1,104,2,99", timeout: 10 * time.Millisecond, expErr: context.DeadlineExceeded},
+		"cancelled before run": {inputCode: "99", expErr: context.Canceled},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			// unbuffered output which is never read
+			testComp, err := NewChannelComputer(tc.inputCode, make(chan int64), make(chan int64))
+			require.NoError(t, err)
+			testComp.DisableLog = true
+			testComp.DisableOutLog = true
+
+			var ctx context.Context
+			var cancel context.CancelFunc
+			if tc.timeout != 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), tc.timeout)
+			} else {
+				ctx, cancel = context.WithCancel(context.Background())
+				cancel()
+			}
+			defer cancel()
+
+			err = testComp.RunContext(ctx)
+			assert.ErrorIs(t, err, tc.expErr)
+			assert.False(t, testComp.Terminated())
+		})
+	}
+}
+
+func TestInOutComputerStop(t *testing.T) {
+	c, err := NewInOutComputer("3,9,4,9,1105,1,0,99,99,0")
+	require.NoError(t, err)
+	assert.Equal(t, 5, c.Input(5))
+
+	// blocked waiting for the next input
+	assert.NoError(t, c.Stop())
+	assert.Equal(t, 0, c.Input(6))
+}
+
+func TestSeriesComputerCancelsOnError(t *testing.T) {
+	// Outputs a non zero input, fails on zero
+	c, err := NewSeriesComputer("3,12,1005,12,9,1,-1,0,0,4,12,99,0", "A", "B")
+	require.NoError(t, err)
+	c.RunAsync()
+	c.Input(0)
+
+	err = c.WaitForCompletion()
+	var execErr *ExecutionError
+	require.ErrorAs(t, err, &execErr)
+	assert.Equal(t, "A", execErr.Label)
+	assert.Equal(t, &OutOfBoundsError{Addr: -1}, execErr.Err)
+}
+
+func TestFeedbackComputerDeadlock(t *testing.T) {
+	// Echoes one input then reads two more before halting
+	c, err := NewFeedbackComputer("3,9,4,9,3,9,3,9,99,0", "A", "B")
+	require.NoError(t, err)
+	c.RunAsync()
+	c.Input(7)
+
+	err = c.WaitForCompletion()
+	assert.ErrorIs(t, err, ErrDeadlock)
+	assert.EqualError(t, err, "deadlock, every running computer is waiting: [A] waiting at 6, last output 7; [B] waiting at 4, last output 7")
+}
@@ -0,0 +1,216 @@
+package intcode
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// SnapshotVersion is the version of the snapshot file format written by WriteSnapshot, version 1 files
+// without queued input and the executed count can still be read
+const SnapshotVersion = 2
+
+// Snapshot is the complete execution state of a computer, it does not include input or output wiring
+type Snapshot struct {
+	InsPtr       int
+	RelativeBase int
+	Terminated   bool
+	Outputs      []int64
+	// Queued holds input values sent with SendInput which have not been read yet
+	Queued []int64
+	// Executed is the number of instructions executed before the snapshot
+	Executed   int
+	bigOutputs map[int]*big.Int
+	memory     *memory
+}
+
+// Snapshot captures the current state of the computer, later changes to the computer do not affect it
+func (c *Computer) Snapshot() *Snapshot {
+	return &Snapshot{
+		InsPtr:       c.insPtr,
+		RelativeBase: c.relativeBase,
+		Terminated:   c.terminated,
+		Outputs:      append([]int64(nil), c.outputs...),
+		Queued:       append([]int64(nil), c.queued...),
+		Executed:     c.executed,
+		bigOutputs:   copyBigOutputs(c.bigOutputs),
+		memory:       c.memory.clone(),
+	}
+}
+
+// Restore replaces the state of the computer with the snapshot, keeping its input and output wiring
+func (c *Computer) Restore(s *Snapshot) {
+	c.memory = s.memory.clone()
+	c.decoded = nil
+	if c.History != nil {
+		c.History.Clear()
+	}
+	c.insPtr = s.InsPtr
+	c.relativeBase = s.RelativeBase
+	c.terminated = s.Terminated
+	c.outputs = append([]int64(nil), s.Outputs...)
+	c.bigOutputs = copyBigOutputs(s.bigOutputs)
+	c.queued = append([]int64(nil), s.Queued...)
+	c.executed = s.Executed
+}
+
+// ReadAddr reads the memory value at an absolute address in the snapshot
+func (s *Snapshot) ReadAddr(addr int) int64 {
+	if addr < 0 {
+		return 0
+	}
+	return s.memory.get(addr)
+}
+
+type snapshotFile struct {
+	Version      int               `json:"version"`
+	InsPtr       int               `json:"insPtr"`
+	RelativeBase int               `json:"relativeBase"`
+	Terminated   bool              `json:"terminated"`
+	Outputs      []int64           `json:"outputs"`
+	Queued       []int64           `json:"queued,omitempty"`
+	Executed     int               `json:"executed"`
+	MemorySize   int               `json:"memorySize"`
+	Memory       []snapshotSegment `json:"memory"`
+	// BigOutputs and BigMemory hold values outside the int64 range as decimal strings by index and address,
+	// the nearest int64 is written in Outputs and Memory
+	BigOutputs map[int]string `json:"bigOutputs,omitempty"`
+	BigMemory  map[int]string `json:"bigMemory,omitempty"`
+}
+
+// snapshotSegment is a run of memory values starting at an address, untouched memory is not written
+type snapshotSegment struct {
+	Addr   int     `json:"addr"`
+	Values []int64 `json:"values"`
+}
+
+// WriteSnapshot writes the snapshot in the versioned JSON snapshot format
+func WriteSnapshot(w io.Writer, s *Snapshot) error {
+	file := snapshotFile{
+		Version:      SnapshotVersion,
+		InsPtr:       s.InsPtr,
+		RelativeBase: s.RelativeBase,
+		Terminated:   s.Terminated,
+		Outputs:      s.Outputs,
+		Queued:       s.Queued,
+		Executed:     s.Executed,
+		MemorySize:   s.memory.len(),
+	}
+
+	var pageNums []int
+	for pageNum := range s.memory.pages {
+		pageNums = append(pageNums, pageNum)
+	}
+	sort.Ints(pageNums)
+
+	for _, pageNum := range pageNums {
+		values := s.memory.pages[pageNum].values[:]
+		for len(values) > 0 && values[len(values)-1] == 0 {
+			values = values[:len(values)-1]
+		}
+		if len(values) == 0 {
+			continue
+		}
+		file.Memory = append(file.Memory, snapshotSegment{Addr: pageNum * pageSize, Values: values})
+	}
+
+	file.BigOutputs = bigStrings(s.bigOutputs)
+	file.BigMemory = bigStrings(s.memory.bigCells)
+
+	encoder := json.NewEncoder(w)
+	return errors.Wrap(encoder.Encode(file), "unable to write snapshot")
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var file snapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, errors.Wrap(err, "unable to read snapshot")
+	}
+	if file.Version < 1 || file.Version > SnapshotVersion {
+		return nil, errors.Errorf("unsupported snapshot version %d (want 1 to %d)", file.Version, SnapshotVersion)
+	}
+
+	mem := newMemory(nil)
+	for _, segment := range file.Memory {
+		if segment.Addr < 0 {
+			return nil, errors.Errorf("invalid snapshot memory address %d", segment.Addr)
+		}
+		for i, v := range segment.Values {
+			mem.set(segment.Addr+i, v)
+		}
+	}
+	for addr, str := range file.BigMemory {
+		val, ok := new(big.Int).SetString(str, 10)
+		if !ok || addr < 0 {
+			return nil, errors.Errorf("invalid snapshot memory value %q at %d", str, addr)
+		}
+		mem.setBig(addr, val)
+	}
+	if file.MemorySize < mem.size {
+		return nil, errors.Errorf("snapshot memory size %d is smaller than its contents (%d)", file.MemorySize, mem.size)
+	}
+	mem.size = file.MemorySize
+
+	var bigOutputs map[int]*big.Int
+	for i, str := range file.BigOutputs {
+		val, ok := new(big.Int).SetString(str, 10)
+		if !ok || i < 0 || i >= len(file.Outputs) {
+			return nil, errors.Errorf("invalid snapshot output %q at %d", str, i)
+		}
+		if bigOutputs == nil {
+			bigOutputs = make(map[int]*big.Int)
+		}
+		bigOutputs[i] = val
+	}
+
+	return &Snapshot{
+		InsPtr:       file.InsPtr,
+		RelativeBase: file.RelativeBase,
+		Terminated:   file.Terminated,
+		Outputs:      file.Outputs,
+		Queued:       file.Queued,
+		Executed:     file.Executed,
+		bigOutputs:   bigOutputs,
+		memory:       mem,
+	}, nil
+}
+
+// bigStrings formats values outside the int64 range for the snapshot format
+func bigStrings(values map[int]*big.Int) map[int]string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[int]string, len(values))
+	for key, val := range values {
+		result[key] = val.String()
+	}
+	return result
+}
+
+// SaveSnapshot writes the snapshot to a file
+func SaveSnapshot(path string, s *Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "unable to create snapshot file")
+	}
+	if err := WriteSnapshot(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	return errors.Wrap(f.Close(), "unable to write snapshot file")
+}
+
+// LoadSnapshot reads a snapshot from a file
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open snapshot file")
+	}
+	defer f.Close()
+	return ReadSnapshot(f)
+}
@@ -0,0 +1,148 @@
+package intcode
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Outputs 1, moves the relative base to 10 then reads a value into rb+0 and outputs it
+const snapshotProgram = "104,1,109,10,203,0,204,0,99"
+
+func TestSnapshotRestore(t *testing.T) {
+	scanner := bufio.NewScanner(bytes.NewBufferString("7\n"))
+	testComp, err := NewComputer(snapshotProgram, scanner)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	for i := 0; i < 3; i++ {
+		require.NoError(t, testComp.Step())
+	}
+
+	snapshot := testComp.Snapshot()
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, []int64{1, 7}, testComp.Outputs())
+
+	tt := map[string]func(*Snapshot) *Snapshot{
+		"in memory": func(s *Snapshot) *Snapshot { return s },
+		"file": func(s *Snapshot) *Snapshot {
+			path := filepath.Join(t.TempDir(), "snapshot.json")
+			require.NoError(t, SaveSnapshot(path, s))
+			loaded, err := LoadSnapshot(path)
+			require.NoError(t, err)
+			return loaded
+		},
+	}
+
+	for name, load := range tt {
+		t.Run(name, func(t *testing.T) {
+			restored, err := NewComputer("99", nil)
+			require.NoError(t, err)
+			restored.DisableLog = true
+			restored.Restore(load(snapshot))
+
+			assert.Equal(t, int64(7), restored.ReadAddr(10))
+			assert.Equal(t, []int64{1}, restored.Outputs())
+
+			require.NoError(t, restored.Run())
+			assert.Equal(t, []int64{1, 7}, restored.Outputs())
+			assert.Equal(t, testComp.DumpMemory(), restored.DumpMemory())
+		})
+	}
+}
+
+func TestSnapshotIsIndependent(t *testing.T) {
+	testComp, err := NewComputer("1,0,0,0,99", nil)
+	require.NoError(t, err)
+	snapshot := testComp.Snapshot()
+
+	require.NoError(t, testComp.Run())
+	assert.Equal(t, int64(1), snapshot.ReadAddr(0))
+	assert.Equal(t, 0, snapshot.InsPtr)
+
+	testComp.Restore(snapshot)
+	testComp.WriteAddr(0, 5)
+	assert.Equal(t, int64(1), snapshot.ReadAddr(0))
+}
+
+func TestSnapshotQueuedInput(t *testing.T) {
+	testComp, err := NewComputer("3,0,4,0,99", nil)
+	require.NoError(t, err)
+	testComp.DisableLog = true
+	testComp.DisableOutLog = true
+	testComp.SendInput(5)
+	withInput := testComp.Snapshot()
+	require.NoError(t, testComp.Run())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSnapshot(&buf, withInput))
+	loaded, err := ReadSnapshot(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, loaded.Queued)
+
+	for _, s := range []*Snapshot{withInput, loaded} {
+		testComp.Restore(s)
+		assert.Equal(t, 0, testComp.executed)
+		require.NoError(t, testComp.Run())
+		assert.Equal(t, []int64{5}, testComp.Outputs())
+		assert.Equal(t, 3, testComp.executed)
+	}
+
+	// values sent after the snapshot are discarded when it is restored
+	empty, err := NewComputer("3,0,4,0,99", nil)
+	require.NoError(t, err)
+	snapshot := empty.Snapshot()
+	empty.SendInput(7)
+	empty.Restore(snapshot)
+	status, err := empty.Resume()
+	require.NoError(t, err)
+	assert.Equal(t, NeedsInput, status)
+}
+
+func TestReadSnapshotVersion1(t *testing.T) {
+	loaded, err := ReadSnapshot(strings.NewReader(`{"version":1,"insPtr":2,"memorySize":5,"memory":[{"addr":0,"values":[3,0,4,0,99]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded.InsPtr)
+	assert.Empty(t, loaded.Queued)
+	assert.Equal(t, 0, loaded.Executed)
+}
+
+func TestSnapshotSparseMemory(t *testing.T) {
+	testComp, err := NewComputer("1101,5,6,250000,99", nil)
+	require.NoError(t, err)
+	require.NoError(t, testComp.Run())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSnapshot(&buf, testComp.Snapshot()))
+	assert.Less(t, buf.Len(), 1000)
+
+	loaded, err := ReadSnapshot(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), loaded.ReadAddr(250000))
+	assert.Equal(t, int64(0), loaded.ReadAddr(249999))
+}
+
+func TestReadSnapshotErrors(t *testing.T) {
+	tt := map[string]struct {
+		data   string
+		expErr string
+	}{
+		"unsupported version": {data: `{"version":3}`, expErr: "unsupported snapshot version 3 (want 1 to 2)"},
+		"missing version":     {data: `{"insPtr":4}`, expErr: "unsupported snapshot version 0 (want 1 to 2)"},
+		"negative address":    {data: `{"version":1,"memory":[{"addr":-4,"values":[1]}]}`, expErr: "invalid snapshot memory address -4"},
+		"size too small":      {data: `{"version":1,"memorySize":1,"memory":[{"addr":0,"values":[1,2]}]}`, expErr: "snapshot memory size 1 is smaller than its contents (2)"},
+		"not json":            {data: `1,0,0,0,99`, expErr: "unable to read snapshot"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadSnapshot(strings.NewReader(tc.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expErr)
+		})
+	}
+}
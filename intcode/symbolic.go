@@ -0,0 +1,537 @@
+package intcode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSymbolicControl is returned when symbolic execution reaches an instruction whose op code, jump or
+// relative base shift depends on a symbolic value, so the path through the program is not known
+var ErrSymbolicControl = errors.New("control flow depends on a symbolic value")
+
+// ErrSymbolicWrite is returned when symbolic execution writes to an address which depends on a symbolic value
+var ErrSymbolicWrite = errors.New("write to a symbolic address")
+
+// ExprOp is the operation of a node in an expression tree
+type ExprOp int
+
+const (
+	// ConstExpr is a known value
+	ConstExpr ExprOp = iota
+	// VarExpr is a named variable
+	VarExpr
+	// AddExpr is the sum of its two args
+	AddExpr
+	// MulExpr is the product of its two args
+	MulExpr
+	// LessThanExpr is 1 if its first arg is less than its second, otherwise 0
+	LessThanExpr
+	// EqualExpr is 1 if its args are equal, otherwise 0
+	EqualExpr
+	// LoadExpr is the value read from the address given by its arg
+	LoadExpr
+)
+
+// Expr is an expression tree built by symbolic execution
+type Expr struct {
+	Op    ExprOp
+	Value int64
+	Name  string
+	Args  []*Expr
+}
+
+// Const creates an expression with a known value
+func Const(val int64) *Expr {
+	return &Expr{Op: ConstExpr, Value: val}
+}
+
+// Var creates a named variable
+func Var(name string) *Expr {
+	return &Expr{Op: VarExpr, Name: name}
+}
+
+// IsConst reports if the expression has a known value
+func (e *Expr) IsConst() bool {
+	return e.Op == ConstExpr
+}
+
+// String gives the expression in closed form, parts which are polynomials of the variables are collected
+// into a sum of terms
+func (e *Expr) String() string {
+	if p, ok := e.Polynomial(); ok {
+		return p.String()
+	}
+	switch e.Op {
+	case AddExpr:
+		return fmt.Sprintf("(%s + %s)", e.Args[0], e.Args[1])
+	case MulExpr:
+		return fmt.Sprintf("(%s * %s)", e.Args[0], e.Args[1])
+	case LessThanExpr:
+		return fmt.Sprintf("(%s < %s)", e.Args[0], e.Args[1])
+	case EqualExpr:
+		return fmt.Sprintf("(%s == %s)", e.Args[0], e.Args[1])
+	case LoadExpr:
+		return fmt.Sprintf("mem[%s]", e.Args[0])
+	}
+	return "?"
+}
+
+// Eval calculates the value of the expression for values of its variables. Loads cannot be evaluated as
+// the memory they read is not known.
+func (e *Expr) Eval(vars map[string]int64) (int64, error) {
+	switch e.Op {
+	case ConstExpr:
+		return e.Value, nil
+	case VarExpr:
+		val, ok := vars[e.Name]
+		if !ok {
+			return 0, errors.Errorf("no value for %s", e.Name)
+		}
+		return val, nil
+	case LoadExpr:
+		return 0, errors.Errorf("unable to evaluate %s", e)
+	}
+	x, err := e.Args[0].Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	y, err := e.Args[1].Eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	return evalBinary(e.Op, x, y), nil
+}
+
+// Vars lists the names of the variables in the expression in sorted order
+func (e *Expr) Vars() []string {
+	seen := map[string]bool{}
+	var walk func(e *Expr)
+	walk = func(e *Expr) {
+		if e.Op == VarExpr {
+			seen[e.Name] = true
+		}
+		for _, arg := range e.Args {
+			walk(arg)
+		}
+	}
+	walk(e)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// evalBinary applies an operation to two values as the computer would
+func evalBinary(op ExprOp, x, y int64) int64 {
+	switch op {
+	case AddExpr:
+		return x + y
+	case MulExpr:
+		return x * y
+	case LessThanExpr:
+		if x < y {
+			return 1
+		}
+	case EqualExpr:
+		if x == y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// binaryExpr builds the result of an operation, folding constants and dropping identities
+func binaryExpr(op ExprOp, x, y *Expr) *Expr {
+	if x.IsConst() && y.IsConst() {
+		return Const(evalBinary(op, x.Value, y.Value))
+	}
+	switch op {
+	case AddExpr:
+		if x.IsConst() && x.Value == 0 {
+			return y
+		}
+		if y.IsConst() && y.Value == 0 {
+			return x
+		}
+	case MulExpr:
+		if (x.IsConst() && x.Value == 0) || (y.IsConst() && y.Value == 0) {
+			return Const(0)
+		}
+		if x.IsConst() && x.Value == 1 {
+			return y
+		}
+		if y.IsConst() && y.Value == 1 {
+			return x
+		}
+	}
+	return &Expr{Op: op, Args: []*Expr{x, y}}
+}
+
+// Term is a coefficient multiplied by a product of variables, a variable appears once for each power
+type Term struct {
+	Coefficient int64
+	Vars        []string
+}
+
+// Polynomial is a sum of terms, ordered by descending degree then by variables, with at most one term for
+// each product of variables
+type Polynomial []Term
+
+// Polynomial collects the expression into a sum of terms, reporting false if it contains comparisons or
+// loads
+func (e *Expr) Polynomial() (Polynomial, bool) {
+	switch e.Op {
+	case ConstExpr:
+		return Polynomial{{Coefficient: e.Value}}.normalize(), true
+	case VarExpr:
+		return Polynomial{{Coefficient: 1, Vars: []string{e.Name}}}, true
+	case AddExpr, MulExpr:
+		x, ok := e.Args[0].Polynomial()
+		if !ok {
+			return nil, false
+		}
+		y, ok := e.Args[1].Polynomial()
+		if !ok {
+			return nil, false
+		}
+		if e.Op == AddExpr {
+			return append(append(Polynomial(nil), x...), y...).normalize(), true
+		}
+		var product Polynomial
+		for _, a := range x {
+			for _, b := range y {
+				vars := append(append([]string(nil), a.Vars...), b.Vars...)
+				sort.Strings(vars)
+				product = append(product, Term{Coefficient: a.Coefficient * b.Coefficient, Vars: vars})
+			}
+		}
+		return product.normalize(), true
+	}
+	return nil, false
+}
+
+// normalize combines terms with the same variables, drops zero terms and sorts the result
+func (p Polynomial) normalize() Polynomial {
+	coefficients := map[string]int64{}
+	vars := map[string][]string{}
+	for _, t := range p {
+		key := strings.Join(t.Vars, "*")
+		coefficients[key] += t.Coefficient
+		vars[key] = t.Vars
+	}
+	var result Polynomial
+	for key, c := range coefficients {
+		if c != 0 {
+			result = append(result, Term{Coefficient: c, Vars: vars[key]})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Vars, result[j].Vars
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return strings.Join(a, "*") < strings.Join(b, "*")
+	})
+	return result
+}
+
+func (p Polynomial) String() string {
+	if len(p) == 0 {
+		return "0"
+	}
+	var sb strings.Builder
+	for i, t := range p {
+		c := t.Coefficient
+		switch {
+		case i == 0 && c < 0:
+			sb.WriteString("-")
+			c = -c
+		case i > 0 && c < 0:
+			sb.WriteString(" - ")
+			c = -c
+		case i > 0:
+			sb.WriteString(" + ")
+		}
+		if c != 1 || len(t.Vars) == 0 {
+			sb.WriteString(fmt.Sprint(c))
+			if len(t.Vars) != 0 {
+				sb.WriteString("*")
+			}
+		}
+		sb.WriteString(strings.Join(t.Vars, "*"))
+	}
+	return sb.String()
+}
+
+// Degree gives the highest number of variables multiplied in any term
+func (p Polynomial) Degree() int {
+	degree := 0
+	for _, t := range p {
+		if len(t.Vars) > degree {
+			degree = len(t.Vars)
+		}
+	}
+	return degree
+}
+
+// SolveFor inverts the polynomial, finding the value of the named variable which makes it equal want given
+// values for every other variable. The polynomial must be linear in the variable once the others are
+// substituted, ErrNoSolution is returned if no integer value works.
+func (p Polynomial) SolveFor(name string, want int64, vars map[string]int64) (int64, error) {
+	var coefficient, rest int64
+	for _, t := range p {
+		val := t.Coefficient
+		power := 0
+		for _, v := range t.Vars {
+			if v == name {
+				power++
+				continue
+			}
+			known, ok := vars[v]
+			if !ok {
+				return 0, errors.Errorf("no value for %s", v)
+			}
+			val *= known
+		}
+		switch power {
+		case 0:
+			rest += val
+		case 1:
+			coefficient += val
+		default:
+			return 0, errors.Errorf("%s is not linear in %s", p, name)
+		}
+	}
+	if coefficient == 0 || (want-rest)%coefficient != 0 {
+		return 0, ErrNoSolution
+	}
+	return (want - rest) / coefficient, nil
+}
+
+// exprOps gives the expression built by each arithmetic and comparison op code
+var exprOps = map[OpCode]ExprOp{
+	OpCodeAdd:      AddExpr,
+	OpCodeMultiply: MulExpr,
+	OpCodeLessThan: LessThanExpr,
+	OpCodeEqual:    EqualExpr,
+}
+
+// Symbolic runs a program with chosen memory cells holding variables rather than values. Add, multiply,
+// less than and equals build expressions from their operands, so once the program halts each cell and
+// output holds a closed form of the variables. The path through the program must not depend on the
+// variables: an op code, jump or relative base shift which does fails with ErrSymbolicControl. Reading from
+// an address which depends on a variable gives a load expression.
+type Symbolic struct {
+	// MaxInstructions limits the run, zero means no limit
+	MaxInstructions int
+
+	comp    *Computer
+	exprs   map[int]*Expr
+	outputs []*Expr
+}
+
+// NewSymbolic creates a symbolic computer for a program, input is provided with SendInput
+func NewSymbolic(inputText string) (*Symbolic, error) {
+	comp, err := NewComputer(inputText, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input to int code memory")
+	}
+	comp.DisableLog = true
+	comp.DisableOutLog = true
+	return &Symbolic{comp: comp, exprs: map[int]*Expr{}}, nil
+}
+
+// SetVar makes a memory cell hold a named variable
+func (s *Symbolic) SetVar(addr int, name string) {
+	s.exprs[addr] = Var(name)
+}
+
+// SendInput queues concrete values to be read by input instructions
+func (s *Symbolic) SendInput(vals ...int64) {
+	s.comp.SendInput(vals...)
+}
+
+// Computer gives the computer holding the concrete memory and state of the run
+func (s *Symbolic) Computer() *Computer {
+	return s.comp
+}
+
+// Expr gives the expression held in a memory cell
+func (s *Symbolic) Expr(addr int) *Expr {
+	if e, ok := s.exprs[addr]; ok {
+		return e
+	}
+	return Const(s.comp.ReadAddr(addr))
+}
+
+// Outputs gives the expression for each value output
+func (s *Symbolic) Outputs() []*Expr {
+	return s.outputs
+}
+
+// Run executes the program until it halts
+func (s *Symbolic) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext executes the program until it halts or the context is done
+func (s *Symbolic) RunContext(ctx context.Context) error {
+	c := s.comp
+	for run := 0; !c.terminated; run++ {
+		if run%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if s.MaxInstructions > 0 && run >= s.MaxInstructions {
+			return c.executionError(c.insPtr, ErrInstructionBudget)
+		}
+		if err := s.step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step executes the instruction at the instruction pointer symbolically
+func (s *Symbolic) step() error {
+	c := s.comp
+	opPtr := c.insPtr
+	if _, ok := s.exprs[opPtr]; ok {
+		return c.executionError(opPtr, ErrSymbolicControl)
+	}
+	op, ok := c.decode(opPtr)
+	if !ok {
+		// invalid instructions fail as they would when run concretely, a halt with modes halts
+		return c.Step()
+	}
+
+	switch op.code {
+	case OpCodeAdd, OpCodeMultiply, OpCodeLessThan, OpCodeEqual:
+		x, err := s.read(op, 0)
+		if err != nil {
+			return err
+		}
+		y, err := s.read(op, 1)
+		if err != nil {
+			return err
+		}
+		if err := s.store(op, 2, binaryExpr(exprOps[op.code], x, y)); err != nil {
+			return err
+		}
+	case OpCodeInput:
+		if _, err := s.addr(op, 0); err != nil {
+			return err
+		}
+		input, err := c.readInput()
+		if err != nil {
+			return c.executionError(opPtr, err)
+		}
+		if err := s.store(op, 0, Const(input)); err != nil {
+			return err
+		}
+	case OpCodeOutput:
+		out, err := s.read(op, 0)
+		if err != nil {
+			return err
+		}
+		s.outputs = append(s.outputs, out)
+	case OpCodeJumpIfTrue, OpCodeJumpIfFalse:
+		cond, err := s.read(op, 0)
+		if err != nil {
+			return err
+		}
+		if !cond.IsConst() {
+			return c.executionError(opPtr, ErrSymbolicControl)
+		}
+		if (cond.Value != 0) != (op.code == OpCodeJumpIfTrue) {
+			break
+		}
+		target, err := s.read(op, 1)
+		if err != nil {
+			return err
+		}
+		if !target.IsConst() {
+			return c.executionError(opPtr, ErrSymbolicControl)
+		}
+		if target.Value < 0 {
+			return c.executionError(opPtr, &OutOfBoundsError{Addr: int(target.Value)})
+		}
+		c.insPtr = int(target.Value)
+		c.executed++
+		return nil
+	case OpCodeShiftRelative:
+		shift, err := s.read(op, 0)
+		if err != nil {
+			return err
+		}
+		if !shift.IsConst() {
+			return c.executionError(opPtr, ErrSymbolicControl)
+		}
+		c.relativeBase += int(shift.Value)
+	case OpCodeHalt:
+		c.terminated = true
+	}
+	c.insPtr = opPtr + op.size
+	c.executed++
+	return nil
+}
+
+// arg gives the expression held in the param of an instruction
+func (s *Symbolic) arg(i int) *Expr {
+	return s.Expr(s.comp.insPtr + 1 + i)
+}
+
+// addr gives the address referenced by a position or relative mode param, failing if it is symbolic. A
+// write param in immediate mode is used as an address as Step does.
+func (s *Symbolic) addr(op decodedOp, i int) (int, error) {
+	c := s.comp
+	if !s.arg(i).IsConst() {
+		return 0, c.executionError(c.insPtr, ErrSymbolicWrite)
+	}
+	addr := c.fastAddr(op.modes[i], op.args[i])
+	if c.addrOutOfBounds(addr) {
+		return 0, c.executionError(c.insPtr, &OutOfBoundsError{Addr: addr})
+	}
+	return addr, nil
+}
+
+// read gives the expression for a param, a symbolic address reads as a load
+func (s *Symbolic) read(op decodedOp, i int) (*Expr, error) {
+	c := s.comp
+	arg := s.arg(i)
+	switch op.modes[i] {
+	case AbsoluteMode:
+		return arg, nil
+	case RelativeMode:
+		arg = binaryExpr(AddExpr, arg, Const(int64(c.relativeBase)))
+	}
+	if !arg.IsConst() {
+		return &Expr{Op: LoadExpr, Args: []*Expr{arg}}, nil
+	}
+	if c.addrOutOfBounds(int(arg.Value)) {
+		return nil, c.executionError(c.insPtr, &OutOfBoundsError{Addr: int(arg.Value)})
+	}
+	return s.Expr(int(arg.Value)), nil
+}
+
+// store writes an expression to the address of a param, concrete values are written to memory
+func (s *Symbolic) store(op decodedOp, i int, e *Expr) error {
+	addr, err := s.addr(op, i)
+	if err != nil {
+		return err
+	}
+	if e.IsConst() {
+		delete(s.exprs, addr)
+		s.comp.memory.set(addr, e.Value)
+	} else {
+		s.exprs[addr] = e
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package intcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolic(t *testing.T) {
+	tt := map[string]struct {
+		inputData  string
+		vars       map[int]string
+		input      []int64
+		addr       int
+		expExpr    string
+		expOutputs []string
+	}{
+		"day 2": {inputData: readProgram(t, "../day02/input.txt"), vars: map[int]string{1: "noun", 2: "verb"}, expExpr: "345600*noun + verb + 337024"},
+		// Multiplies address 9 by address 10, less seven
+		"product": {inputData: "2,9,10,0,1001,0,-7,0,99,0,0", vars: map[int]string{9: "a", 10: "b"}, expExpr: "a*b - 7"},
+		// Outputs if address 9 is less than five
+		"comparison": {inputData: "1007,9,5,10,4,10,99,0,0,0,0", vars: map[int]string{9: "x"}, addr: 10, expExpr: "(x < 5)", expOutputs: []string{"(x < 5)"}},
+		// Squares rb+0 into rb+1 and outputs it
+		"relative": {inputData: "109,10,22202,0,0,1,4,11,99,0,0,0", vars: map[int]string{10: "x"}, addr: 11, expExpr: "x*x", expOutputs: []string{"x*x"}},
+		// Adds the value at the address held in address 1 to address 9
+		"load": {inputData: "1,1,9,0,99,0,0,0,0,5", vars: map[int]string{1: "p"}, expExpr: "(mem[p] + 5)"},
+		// Jumps over a halt to add one to address 9
+		"concrete jump": {inputData: "1105,1,4,99,1001,9,1,9,99,0", vars: map[int]string{9: "x"}, addr: 9, expExpr: "x + 1"},
+		// Adds input to address 8
+		"input": {inputData: "3,7,1,7,8,0,99,0,0", vars: map[int]string{8: "y"}, input: []int64{4}, expExpr: "y + 4"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSymbolic(tc.inputData)
+			require.NoError(t, err)
+			for addr, name := range tc.vars {
+				s.SetVar(addr, name)
+			}
+			s.SendInput(tc.input...)
+
+			require.NoError(t, s.Run())
+			assert.True(t, s.Computer().Terminated())
+			assert.Equal(t, tc.expExpr, s.Expr(tc.addr).String())
+			var outputs []string
+			for _, out := range s.Outputs() {
+				outputs = append(outputs, out.String())
+			}
+			assert.Equal(t, tc.expOutputs, outputs)
+		})
+	}
+}
+
+func TestSymbolicErrors(t *testing.T) {
+	tt := map[string]struct {
+		inputData string
+		vars      map[int]string
+		expErr    error
+		expMsg    string
+	}{
+		"symbolic op code": {inputData: "1,0,0,0,99", vars: map[int]string{0: "x"}, expErr: ErrSymbolicControl, expMsg: "instruction at 0 (relative base 0): control flow depends on a symbolic value"},
+		"symbolic jump":    {inputData: "1005,4,0,99,0", vars: map[int]string{4: "x"}, expErr: ErrSymbolicControl},
+		"symbolic shift":   {inputData: "109,1,9,5,99,0", vars: map[int]string{5: "x"}, expErr: ErrSymbolicControl, expMsg: "instruction at 2 (relative base 1): control flow depends on a symbolic value"},
+		"symbolic write":   {inputData: "1101,1,1,5,99,0", vars: map[int]string{3: "p"}, expErr: ErrSymbolicWrite},
+		"no input":         {inputData: "3,0,99", expErr: ErrNoInput},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSymbolic(tc.inputData)
+			require.NoError(t, err)
+			for addr, name := range tc.vars {
+				s.SetVar(addr, name)
+			}
+
+			err = s.Run()
+			assert.ErrorIs(t, err, tc.expErr)
+			if tc.expMsg != "" {
+				assert.EqualError(t, err, tc.expMsg)
+			}
+		})
+	}
+}
+
+func TestPolynomialSolveFor(t *testing.T) {
+	x, y := Var("x"), Var("y")
+	tt := map[string]struct {
+		expr   *Expr
+		want   int64
+		vars   map[string]int64
+		expVal int64
+		expErr string
+	}{
+		"linear":        {expr: binaryExpr(AddExpr, binaryExpr(MulExpr, x, Const(100)), y), want: 1234, vars: map[string]int64{"y": 34}, expVal: 12},
+		"negative":      {expr: binaryExpr(AddExpr, binaryExpr(MulExpr, x, Const(-3)), Const(7)), want: 1, expVal: 2},
+		"product":       {expr: binaryExpr(MulExpr, x, y), want: 42, vars: map[string]int64{"y": 6}, expVal: 7},
+		"not divisible": {expr: binaryExpr(MulExpr, x, Const(4)), want: 6, expErr: ErrNoSolution.Error()},
+		"unused":        {expr: binaryExpr(AddExpr, y, Const(1)), want: 6, vars: map[string]int64{"y": 5}, expErr: ErrNoSolution.Error()},
+		"square":        {expr: binaryExpr(MulExpr, x, x), want: 4, expErr: "x*x is not linear in x"},
+		"missing value": {expr: binaryExpr(AddExpr, x, y), want: 4, expErr: "no value for y"},
+	}
+
+	for name, tc := range tt {
+		t.Run(name, func(t *testing.T) {
+			p, ok := tc.expr.Polynomial()
+			require.True(t, ok)
+
+			val, err := p.SolveFor("x", tc.want, tc.vars)
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expVal, val)
+
+			vars := map[string]int64{"x": val}
+			for name, v := range tc.vars {
+				vars[name] = v
+			}
+			result, err := tc.expr.Eval(vars)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package intcode
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// binaryTraceMagic starts every binary trace, the final byte is the format version
+const binaryTraceMagic = "ICT\x01"
+
+// TraceEvent is a structured record of a single executed instruction
+type TraceEvent struct {
+	Step   int    `json:"step"`
+	Addr   int    `json:"addr"`
+	OpCode OpCode `json:"opcode"`
+	// Operands are the values of every param read by the instruction, after modes were applied
+	Operands []int64     `json:"operands"`
+	Write    *TraceWrite `json:"write,omitempty"`
+	// RelativeBase is the relative base when the instruction started
+	RelativeBase int `json:"relativeBase"`
+}
+
+// TraceWrite is the memory written by an instruction
+type TraceWrite struct {
+	Addr  int   `json:"addr"`
+	Value int64 `json:"value"`
+}
+
+// Tracer receives an event after each instruction executes successfully
+type Tracer interface {
+	Trace(e TraceEvent)
+}
+
+// TracerFunc adapts a function to a Tracer
+type TracerFunc func(e TraceEvent)
+
+// Trace calls the function
+func (f TracerFunc) Trace(e TraceEvent) {
+	f(e)
+}
+
+// traceOperand records a param value read by the instruction being traced
+func (c *Computer) traceOperand(val int64) {
+	if c.trace != nil {
+		c.trace.Operands = append(c.trace.Operands, val)
+	}
+}
+
+// TextTracer writes a human readable line per instruction
+type TextTracer struct {
+	w   io.Writer
+	err error
+}
+
+// NewTextTracer creates a tracer writing lines such as "      12     40: ADD  3, 4 -> [7] = 7 (rb 0)"
+func NewTextTracer(w io.Writer) *TextTracer {
+	return &TextTracer{w: w}
+}
+
+// Trace writes the event, nothing more is written after a write fails
+func (t *TextTracer) Trace(e TraceEvent) {
+	if t.err != nil {
+		return
+	}
+	operands := make([]string, len(e.Operands))
+	for i, v := range e.Operands {
+		operands[i] = strconv.FormatInt(v, 10)
+	}
+	line := strings.TrimRight(fmt.Sprintf("%8d %6d: %-4s %s", e.Step, e.Addr, e.OpCode, strings.Join(operands, ", ")), " ")
+	if e.Write != nil {
+		line += fmt.Sprintf(" -> [%d] = %d", e.Write.Addr, e.Write.Value)
+	}
+	_, t.err = fmt.Fprintf(t.w, "%s (rb %d)\n", line, e.RelativeBase)
+}
+
+// Err returns the first error writing the trace
+func (t *TextTracer) Err() error {
+	return t.err
+}
+
+// JSONTracer writes an event per line as JSON
+type JSONTracer struct {
+	encoder *json.Encoder
+	err     error
+}
+
+// NewJSONTracer creates a tracer writing JSON Lines, each event includes the op mnemonic as well as the code
+func NewJSONTracer(w io.Writer) *JSONTracer {
+	return &JSONTracer{encoder: json.NewEncoder(w)}
+}
+
+// Trace writes the event, nothing more is written after a write fails
+func (t *JSONTracer) Trace(e TraceEvent) {
+	if t.err != nil {
+		return
+	}
+	if e.Operands == nil {
+		e.Operands = []int64{}
+	}
+	t.err = t.encoder.Encode(struct {
+		TraceEvent
+		Op string `json:"op"`
+	}{TraceEvent: e, Op: e.OpCode.String()})
+}
+
+// Err returns the first error writing the trace
+func (t *JSONTracer) Err() error {
+	return t.err
+}
+
+// BinaryTracer writes a compact binary log which can be read back with ReadBinaryTrace. The log starts with
+// a 4 byte header, then each event is a sequence of varints: step, address, op code, operand count, each
+// operand, a write flag, the written address and value if the flag is set, and the relative base.
+type BinaryTracer struct {
+	w           io.Writer
+	buf         []byte
+	wroteHeader bool
+	err         error
+}
+
+// NewBinaryTracer creates a tracer writing the compact binary format
+func NewBinaryTracer(w io.Writer) *BinaryTracer {
+	return &BinaryTracer{w: w}
+}
+
+// Trace writes the event, nothing more is written after a write fails
+func (t *BinaryTracer) Trace(e TraceEvent) {
+	if t.err != nil {
+		return
+	}
+	t.buf = t.buf[:0]
+	if !t.wroteHeader {
+		t.buf = append(t.buf, binaryTraceMagic...)
+		t.wroteHeader = true
+	}
+	t.buf = appendUvarint(t.buf, uint64(e.Step))
+	t.buf = appendUvarint(t.buf, uint64(e.Addr))
+	t.buf = appendUvarint(t.buf, uint64(e.OpCode))
+	t.buf = appendUvarint(t.buf, uint64(len(e.Operands)))
+	for _, v := range e.Operands {
+		t.buf = appendVarint(t.buf, v)
+	}
+	if e.Write != nil {
+		t.buf = append(t.buf, 1)
+		t.buf = appendUvarint(t.buf, uint64(e.Write.Addr))
+		t.buf = appendVarint(t.buf, e.Write.Value)
+	} else {
+		t.buf = append(t.buf, 0)
+	}
+	t.buf = appendVarint(t.buf, int64(e.RelativeBase))
+	_, t.err = t.w.Write(t.buf)
+}
+
+// Err returns the first error writing the trace
+func (t *BinaryTracer) Err() error {
+	return t.err
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var scratch [binary.MaxVarintLen64]byte
+	return append(buf, scratch[:binary.PutUvarint(scratch[:], v)]...)
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var scratch [binary.MaxVarintLen64]byte
+	return append(buf, scratch[:binary.PutVarint(scratch[:], v)]...)
+}
+
+// ReadBinaryTrace reads every event from a log written by a BinaryTracer
+func ReadBinaryTrace(r io.Reader) ([]TraceEvent, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(binaryTraceMagic))
+	if _, err := io.ReadFull(br, header); err != nil {
+		if err == io.EOF {
+			// nothing was traced
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read trace header")
+	}
+	if string(header) != binaryTraceMagic {
+		return nil, errors.New("not a binary trace or unsupported version")
+	}
+
+	var events []TraceEvent
+	for {
+		if _, err := br.Peek(1); err == io.EOF {
+			return events, nil
+		}
+		e, err := readBinaryEvent(br)
+		if err != nil {
+			return events, errors.Wrapf(err, "unable to read trace event %d", len(events))
+		}
+		events = append(events, e)
+	}
+}
+
+func readBinaryEvent(br *bufio.Reader) (TraceEvent, error) {
+	var e TraceEvent
+	var header [4]uint64
+	for i := range header {
+		v, err := binary.ReadUvarint(br)
+		if err != nil {
+			return e, err
+		}
+		header[i] = v
+	}
+	e.Step, e.Addr, e.OpCode = int(header[0]), int(header[1]), OpCode(header[2])
+	for i := uint64(0); i < header[3]; i++ {
+		v, err := binary.ReadVarint(br)
+		if err != nil {
+			return e, err
+		}
+		e.Operands = append(e.Operands, v)
+	}
+
+	wrote, err := br.ReadByte()
+	if err != nil {
+		return e, err
+	}
+	if wrote == 1 {
+		addr, err := binary.ReadUvarint(br)
+		if err != nil {
+			return e, err
+		}
+		val, err := binary.ReadVarint(br)
+		if err != nil {
+			return e, err
+		}
+		e.Write = &TraceWrite{Addr: int(addr), Value: val}
+	}
+
+	rb, err := binary.ReadVarint(br)
+	if err != nil {
+		return e, err
+	}
+	e.RelativeBase = int(rb)
+	return e, nil
+}
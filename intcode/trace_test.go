@@ -0,0 +1,90 @@
+package intcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Adds 3 and 4 into address 9, moves the relative base by 2 then outputs rb+7
+const traceProgram = "1101,3,4,9,109,2,204,7,99,0"
+
+var traceEvents = []TraceEvent{
+	{Step: 0, Addr: 0, OpCode: OpCodeAdd, Operands: []int64{3, 4}, Write: &TraceWrite{Addr: 9, Value: 7}},
+	{Step: 1, Addr: 4, OpCode: OpCodeShiftRelative, Operands: []int64{2}},
+	{Step: 2, Addr: 6, OpCode: OpCodeOutput, Operands: []int64{7}, RelativeBase: 2},
+	{Step: 3, Addr: 8, OpCode: OpCodeHalt, RelativeBase: 2},
+}
+
+func runTraced(t *testing.T, tracer Tracer, fast bool) {
+	testComp, err := NewComputer(traceProgram, nil)
+	require.NoError(t, err)
+	testComp.LogWriter = &bytes.Buffer{}
+	testComp.Tracer = tracer
+	testComp.Fast = fast
+	require.NoError(t, testComp.Run())
+}
+
+func TestTraceEvents(t *testing.T) {
+	for name, fast := range map[string]bool{"step": false, "fast": true} {
+		t.Run(name, func(t *testing.T) {
+			var events []TraceEvent
+			runTraced(t, TracerFunc(func(e TraceEvent) { events = append(events, e) }), fast)
+			assert.Equal(t, traceEvents, events)
+		})
+	}
+}
+
+func TestTextTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewTextTracer(&buf)
+	runTraced(t, tracer, false)
+
+	require.NoError(t, tracer.Err())
+	assert.Equal(t, strings.Join([]string{
+		"       0      0: ADD  3, 4 -> [9] = 7 (rb 0)",
+		"       1      4: ARB  2 (rb 0)",
+		"       2      6: OUT  7 (rb 2)",
+		"       3      8: HALT (rb 2)",
+	}, "\n")+"\n", buf.String())
+}
+
+func TestJSONTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewJSONTracer(&buf)
+	runTraced(t, tracer, false)
+
+	require.NoError(t, tracer.Err())
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"step":0,"addr":0,"opcode":1,"op":"ADD","operands":[3,4],"write":{"addr":9,"value":7},"relativeBase":0}`, lines[0])
+	assert.JSONEq(t, `{"step":3,"addr":8,"opcode":99,"op":"HALT","operands":[],"relativeBase":2}`, lines[3])
+}
+
+func TestBinaryTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewBinaryTracer(&buf)
+	runTraced(t, tracer, false)
+	require.NoError(t, tracer.Err())
+
+	events, err := ReadBinaryTrace(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, traceEvents, events)
+
+	_, err = ReadBinaryTrace(strings.NewReader("not a trace"))
+	assert.EqualError(t, err, "not a binary trace or unsupported version")
+}
+
+func TestLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	testComp, err := NewComputer("1101,3,4,5,99,0", nil)
+	require.NoError(t, err)
+	testComp.LogWriter = &buf
+	require.NoError(t, testComp.Run())
+
+	assert.Contains(t, buf.String(), "ADD : 3 + 4 = 7\n")
+	assert.Contains(t, buf.String(), "HALT")
+}